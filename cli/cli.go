@@ -0,0 +1,445 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"trickster/core"
+	"trickster/utils"
+)
+
+// ================================================================
+// MODO NO INTERACTIVO
+//
+// Expone los tres módulos como subcomandos para poder llamar a
+// Trickster desde scripts y Makefiles:
+//
+//   trickster masks    -in palabras.txt -o salida.txt
+//   trickster variants -words carlos,boca -cap -leet -o salida.txt
+//   trickster profile  -name carlos -birthdate 15031990 -o salida.txt
+//   trickster profile  -profile objetivo.yaml -o salida.txt
+//
+// Cada flag corresponde a una pregunta del modo interactivo.
+// ================================================================
+
+// Run interpreta los argumentos (sin el nombre del binario) y ejecuta el subcomando.
+func Run(args []string) error {
+	if len(args) == 0 {
+		usage()
+		return nil
+	}
+
+	var err error
+	switch args[0] {
+	case "masks":
+		err = runMasks(args[1:])
+	case "variants":
+		err = runVariants(args[1:])
+	case "profile":
+		err = runProfile(args[1:])
+	case "help", "-h", "-help", "--help":
+		usage()
+		return nil
+	default:
+		usage()
+		return fmt.Errorf("subcomando desconocido: %q", args[0])
+	}
+
+	// -h dentro de un subcomando ya imprimió la ayuda: no es un error
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Uso: trickster [subcomando] [flags]
+
+Sin argumentos se abre el menú interactivo.
+
+Subcomandos:
+  masks      Crear máscaras desde una wordlist existente
+  variants   Crear variantes guiadas a partir de palabras base
+  profile    Perfil avanzado del objetivo (modo completo)
+
+Usá "trickster <subcomando> -h" para ver los flags de cada uno.`)
+}
+
+func runMasks(args []string) error {
+	fs := flag.NewFlagSet("masks", flag.ContinueOnError)
+	opts := core.MasksOptions{}
+	fs.StringVar(&opts.InputPath, "in", "", "ruta de la wordlist de entrada (requerido)")
+	dedupFlags(fs, &opts.Dedup)
+	policy := policyFlags(fs, &core.Policy{})
+	refDateFlag(fs)
+	outputFlag(fs, &opts.OutputPath)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := required(fs, "in", opts.InputPath); err != nil {
+		return err
+	}
+	if err := required(fs, "o", opts.OutputPath); err != nil {
+		return err
+	}
+	var err error
+	if opts.Policy, err = policy(); err != nil {
+		return err
+	}
+	return core.RunMasksWith(opts)
+}
+
+func runVariants(args []string) error {
+	fs := flag.NewFlagSet("variants", flag.ContinueOnError)
+	opts := core.VariantsOptions{}
+	var words string
+	fs.StringVar(&words, "words", "", "palabras base separadas por comas (requerido)")
+	fs.BoolVar(&opts.Upper, "upper", false, "agregar versión en MAYÚSCULAS")
+	fs.BoolVar(&opts.Cap, "cap", false, "agregar versión Capitalizada")
+	fs.BoolVar(&opts.Leet, "leet", false, "agregar versión l33tspeak")
+	fs.BoolVar(&opts.Reverse, "reverse", false, "agregar versión al revés")
+	fs.StringVar(&opts.CustomSuffix, "suffix", "", "sufijo personalizado (ej: 2024, @empresa)")
+	fs.BoolVar(&opts.DefaultSuffixes, "common-suffixes", false, "agregar sufijos numéricos comunes (1, 123, 1234, !)")
+	policy := policyFlags(fs, &core.Policy{})
+	refDateFlag(fs)
+	outputFlag(fs, &opts.OutputPath)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts.Bases = utils.SplitList(words)
+	if err := required(fs, "words", strings.Join(opts.Bases, ",")); err != nil {
+		return err
+	}
+	if err := required(fs, "o", opts.OutputPath); err != nil {
+		return err
+	}
+	var err error
+	if opts.Policy, err = policy(); err != nil {
+		return err
+	}
+	return core.RunVariantsWith(opts)
+}
+
+func runProfile(args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	opts := core.ProfilerOptions{}
+	p := &opts.Profile
+
+	var birthDate, profilePath, savePath, modules, disabled string
+	var listModules bool
+	var oldPasses, relatives, dates listFlag
+
+	fs.StringVar(&profilePath, "profile", "", "cargar el perfil desde un archivo .json/.yaml (los flags tienen prioridad)")
+	fs.StringVar(&savePath, "save-profile", "", "guardar el perfil resultante en un archivo .json/.yaml")
+	fs.StringVar(&p.Locale, "locale", "", "pack de localidad: "+strings.Join(core.LocaleCodes(), ", ")+" (default "+core.DefaultLocale+")")
+	fs.StringVar(&p.Nombre, "name", "", "nombre")
+	fs.StringVar(&p.Apellido, "surname", "", "apellido")
+	fs.StringVar(&p.SegundoApellido, "second-surname", "", "segundo apellido (materno)")
+	fs.StringVar(&p.Sexo, "sex", "", "sexo H/M (CURP y apodos)")
+	fs.StringVar(&p.EstadoNacimiento, "birth-state", "", "estado de nacimiento, código o nombre (CURP)")
+	fs.StringVar(&p.DNI, "dni", "", "DNI / cédula / ID")
+	fs.StringVar(&birthDate, "birthdate", "", `fecha de nacimiento (15031990, 15/03/1990, "15 de marzo de 1990", 1990...)`)
+	fs.StringVar(&p.EquipoFutbol, "team", "", "equipo de fútbol favorito")
+	fs.StringVar(&p.Mascota, "pet", "", "nombre de mascota")
+	fs.StringVar(&p.Pareja, "partner", "", "nombre de pareja / familiar cercano")
+	fs.StringVar(&p.Edad, "age", "", "edad actual (se calcula sola si se pasa -birthdate)")
+	fs.StringVar(&p.Ciudad, "city", "", "ciudad")
+	fs.StringVar(&p.Celular, "mobile", "", "celular (ej: \"11 2345-6789\", \"+54 9 341 555-1234\")")
+	fs.StringVar(&p.Telefono, "phone", "", "teléfono fijo")
+	fs.StringVar(&p.Marca, "car-brand", "", "marca del auto")
+	fs.StringVar(&p.Modelo, "car-model", "", "modelo del auto")
+	fs.StringVar(&p.Patente, "plate", "", "patente (AAA123 o AA123BB)")
+	fs.Var(&oldPasses, "old-pass", "contraseña antigua (repetible, hasta 3)")
+	fs.Var(&relatives, "relative", `familiar/mascota "nombre:vínculo:año" (repetible, hasta 10)`)
+	fs.Var(&dates, "date", `fecha significativa "etiqueta:fecha" (repetible, hasta 10)`)
+	fs.Func("nicknames", "diccionario de apodos propio nombre,sexo,apodos[,idioma] (repetible)", core.LoadNicknameFile)
+	fs.BoolVar(&opts.DNIRange, "dni-range", false, "generar candidatos de DNI por rango generacional (requiere fecha y sin -dni)")
+	fs.IntVar(&opts.DNIStep, "dni-step", core.DefaultDNIStep, "densidad del rango de DNI")
+	fs.Func("id-table", "tabla año → documento propia en CSV anio,mediana,dispersion (reemplaza la del pack)", func(v string) error {
+		t, err := core.LoadIDTable(v)
+		opts.IDTable = t
+		return err
+	})
+	fs.IntVar(&opts.Workers, "workers", 0, "goroutines de generación (0 = una por CPU, 1 = secuencial)")
+	fs.BoolVar(&opts.Candidates.Rank, "rank", false, "ordenar la salida por probabilidad (deduplica en memoria, ignora -dedup)")
+	fs.StringVar(&modules, "modules", "", "módulos a ejecutar, en orden, separados por comas (default: todos)")
+	fs.StringVar(&disabled, "disable", "", "módulos a desactivar, separados por comas")
+	fs.BoolVar(&listModules, "list-modules", false, "listar los módulos disponibles y salir")
+	fs.IntVar(&opts.Candidates.Limit, "limit", 0, "quedarse con los N candidatos de mejor puntaje (0 = sin límite, ignora -dedup)")
+	opts.ModuleLimits = make(map[string]int)
+	fs.Func("module-limit", "límite por módulo \"módulo=N\" (repetible; "+strings.Join(core.GeneratorNames(), ", ")+")", func(v string) error {
+		name, value, _ := strings.Cut(v, "=")
+		return core.SetModuleLimit(opts.ModuleLimits, name, value)
+	})
+	dedupFlags(fs, &opts.Candidates.Dedup)
+	policy := policyFlags(fs, core.DefaultPolicy())
+	refDateFlag(fs)
+	outputFlag(fs, &opts.OutputPath)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if listModules {
+		for _, g := range core.Generators() {
+			fmt.Printf("%-12s %s\n", g.Name(), g.Description())
+		}
+		return nil
+	}
+	if err := required(fs, "o", opts.OutputPath); err != nil {
+		return err
+	}
+	var err error
+	if opts.Candidates.Policy, err = policy(); err != nil {
+		return err
+	}
+	opts.Modules, opts.Disabled = utils.SplitList(modules), utils.SplitList(disabled)
+	if _, err := core.SelectGenerators(opts.Modules, opts.Disabled); err != nil {
+		return err
+	}
+
+	if profilePath != "" {
+		if err := loadProfileBase(fs, profilePath, &opts); err != nil {
+			return err
+		}
+	}
+	if birthDate != "" {
+		d, err := p.SetFechaNacimiento(birthDate)
+		if err != nil {
+			return fmt.Errorf("-birthdate: %w", err)
+		}
+		core.WarnAmbiguousDate(birthDate, d)
+	}
+
+	if len(oldPasses) > 3 {
+		return fmt.Errorf("-old-pass admite hasta 3 contraseñas (se pasaron %d)", len(oldPasses))
+	}
+	oldSlots := []*string{&p.OldPass1, &p.OldPass2, &p.OldPass3}
+	if len(oldPasses) > 0 {
+		// Las contraseñas de los flags reemplazan a las del archivo
+		for _, slot := range oldSlots {
+			*slot = ""
+		}
+	}
+	for i, old := range oldPasses {
+		*oldSlots[i] = old
+	}
+
+	if len(relatives) > 0 {
+		// Los familiares de los flags reemplazan a los del archivo
+		opts.Relatives.Parientes = nil
+	}
+	for _, spec := range relatives {
+		rel, err := parseRelative(spec)
+		if err != nil {
+			return err
+		}
+		opts.Relatives.Parientes = append(opts.Relatives.Parientes, rel)
+	}
+	if n := len(opts.Relatives.Parientes); n > 10 {
+		return fmt.Errorf("se admiten hasta 10 familiares/mascotas (hay %d)", n)
+	}
+
+	if len(dates) > 10 {
+		return fmt.Errorf("-date admite hasta 10 fechas (se pasaron %d)", len(dates))
+	}
+	if len(dates) > 0 {
+		// Las fechas de los flags reemplazan a las del archivo
+		p.Fechas = nil
+	}
+	for _, spec := range dates {
+		d, err := parseDate(spec)
+		if err != nil {
+			return err
+		}
+		p.Fechas = append(p.Fechas, d)
+	}
+
+	locale, err := core.LookupLocale(p.Locale)
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(locale); err != nil {
+		return err
+	}
+
+	if savePath != "" {
+		if err := core.SaveProfileFile(savePath, opts.Profile, opts.Relatives); err != nil {
+			return err
+		}
+		utils.Success("Perfil guardado en: " + savePath)
+	}
+
+	return core.RunProfilerWith(opts)
+}
+
+// loadProfileBase carga el perfil desde archivo y vuelve a aplicar encima
+// los flags escalares que se pasaron explícitamente.
+func loadProfileBase(fs *flag.FlagSet, path string, opts *core.ProfilerOptions) error {
+	explicit := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		// Solo los flags escalares (string, int, bool...) implementan Getter;
+		// los repetibles y los de fs.Func acumulan y no se pueden reaplicar
+		if _, scalar := f.Value.(flag.Getter); scalar {
+			explicit[f.Name] = f.Value.String()
+		}
+	})
+
+	p, rp, err := core.LoadProfileFile(path)
+	if err != nil {
+		return err
+	}
+	opts.Profile, opts.Relatives = p, rp
+
+	for name, value := range explicit {
+		if err := fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ── helpers ───────────────────────────────────────────────────────
+
+// listFlag permite repetir un flag: -old-pass a -old-pass b
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// outputFlag registra -o y su alias -output sobre la misma variable.
+func outputFlag(fs *flag.FlagSet, dst *string) {
+	fs.StringVar(dst, "o", "", "ruta de salida de la wordlist (requerido)")
+	fs.StringVar(dst, "output", "", "alias de -o")
+}
+
+// refDateFlag registra -ref-date, la fecha desde la que se cuentan los
+// años recientes (sufijos, hijos, mascotas).
+func refDateFlag(fs *flag.FlagSet) {
+	fs.Func("ref-date", "fecha de referencia del engagement para los años recientes (default: hoy)", func(v string) error {
+		d, err := core.SetReferenceDate(v)
+		if err != nil {
+			return err
+		}
+		core.WarnAmbiguousDate(v, d)
+		return nil
+	})
+}
+
+// dedupFlags registra los flags que eligen y ajustan el backend de deduplicación.
+func dedupFlags(fs *flag.FlagSet, cfg *utils.DedupConfig) {
+	fs.StringVar(&cfg.Backend, "dedup", utils.DedupExact,
+		"backend de deduplicación: "+strings.Join(utils.DedupBackends, ", "))
+	fs.Float64Var(&cfg.FPRate, "bloom-fp", 0.001, "bloom: tasa de falsos positivos")
+	fs.IntVar(&cfg.Expected, "bloom-expected", 10_000_000, "bloom: cantidad esperada de candidatos")
+	fs.StringVar(&cfg.TempDir, "dedup-tmp", "", "disk: directorio para bloques temporales (default: el del sistema)")
+	fs.IntVar(&cfg.ChunkSize, "dedup-chunk", 1_000_000, "disk: candidatos por bloque ordenado en memoria")
+}
+
+// policyFlags registra los flags de la política de contraseñas, con los
+// valores de def como defaults. Devuelve una función que, después de
+// fs.Parse, arma la política final: el preset de -policy (si hay) con los
+// flags de política pasados explícitamente aplicados encima.
+func policyFlags(fs *flag.FlagSet, def *core.Policy) func() (*core.Policy, error) {
+	p := def
+	var preset string
+	names := make([]string, len(core.PolicyPresets))
+	for i, pr := range core.PolicyPresets {
+		names[i] = pr.Name
+	}
+
+	fs.StringVar(&preset, "policy", "", "preset de política: "+strings.Join(names, ", "))
+	fs.IntVar(&p.MinLen, "min-len", p.MinLen, "política: longitud mínima")
+	fs.IntVar(&p.MaxLen, "max-len", p.MaxLen, "política: longitud máxima (0 = sin máximo)")
+	fs.IntVar(&p.MaxBytes, "max-bytes", p.MaxBytes, "política: máximo de bytes UTF-8 (0 = sin máximo)")
+	fs.Func("require", "política: clases obligatorias separadas por comas ("+strings.Join(core.PolicyClasses, ", ")+")", func(v string) error {
+		p.Require = append(p.Require, utils.SplitList(v)...)
+		return nil
+	})
+	fs.IntVar(&p.MinClasses, "min-classes", p.MinClasses, "política: cantidad mínima de clases distintas")
+	fs.StringVar(&p.Charset, "charset", p.Charset, "política: caracteres permitidos, como clase de regexp (ej: a-zA-Z0-9!@#)")
+	fs.StringVar(&p.Pattern, "regex", p.Pattern, "política: regexp que debe cumplir cada candidato")
+	fs.IntVar(&p.MaxRepeat, "max-repeat", p.MaxRepeat, "política: máximo de caracteres iguales seguidos (0 = sin límite)")
+
+	return func() (*core.Policy, error) {
+		if preset == "" {
+			return p, p.Compile()
+		}
+		base, err := core.PresetPolicy(preset)
+		if err != nil {
+			return nil, err
+		}
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "min-len":
+				base.MinLen = p.MinLen
+			case "max-len":
+				base.MaxLen = p.MaxLen
+			case "max-bytes":
+				base.MaxBytes = p.MaxBytes
+			case "require":
+				base.Require = p.Require
+			case "min-classes":
+				base.MinClasses = p.MinClasses
+			case "charset":
+				base.Charset = p.Charset
+			case "regex":
+				base.Pattern = p.Pattern
+			case "max-repeat":
+				base.MaxRepeat = p.MaxRepeat
+			}
+		})
+		return base, base.Compile()
+	}
+}
+
+// required devuelve un error de uso si un flag obligatorio quedó vacío.
+func required(fs *flag.FlagSet, name, value string) error {
+	if strings.TrimSpace(value) != "" {
+		return nil
+	}
+	fs.Usage()
+	return fmt.Errorf("%s: falta el flag requerido -%s", fs.Name(), name)
+}
+
+// parseDate interpreta "etiqueta:fecha" o solo la fecha, en cualquier
+// formato que entienda core.ParseDate.
+func parseDate(spec string) (core.SignificantDate, error) {
+	label, raw, ok := strings.Cut(spec, ":")
+	if !ok {
+		label, raw = "", spec
+	}
+	d, err := core.ParseDate(raw)
+	if err != nil {
+		return core.SignificantDate{}, fmt.Errorf("-date: %w", err)
+	}
+	core.WarnAmbiguousDate(raw, d)
+	return core.SignificantDate{Etiqueta: strings.TrimSpace(label), Fecha: d.Compact()}, nil
+}
+
+// parseRelative interpreta "nombre:vínculo:año"; vínculo y año son opcionales.
+func parseRelative(spec string) (core.Relative, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	rel := core.Relative{
+		Nombre:   strings.TrimSpace(parts[0]),
+		TipoVinc: strings.TrimSpace(parts[1]),
+		AnioNac:  strings.TrimSpace(parts[2]),
+	}
+	if rel.Nombre == "" {
+		return rel, fmt.Errorf("-relative %q: falta el nombre", spec)
+	}
+	if err := core.ValidYear(rel.AnioNac); err != nil {
+		return rel, fmt.Errorf("-relative %q: %w", spec, err)
+	}
+	return rel, nil
+}
@@ -2,15 +2,16 @@ package core
 
 import (
 	"strconv"
-	"strings"
-	"trickster/transforms"
+	"trickster/utils"
 )
 
 // ================================================================
-// MÓDULO: PATRONES LOCALES ARGENTINA
+// PACK DE LOCALIDAD: ARGENTINA
 //
 // Complementa profiler.go con patrones específicos del contexto
 // argentino/rioplatense no cubiertos por las listas genéricas de CUPP.
+// Es el pack por defecto (ver locale.go); Uruguay reutiliza sus
+// costumbres de año duplicado y duplicación de letras.
 //
 // Fuentes:
 //   - Análisis de leaks de foros argentinos (foros.biz, etc.)
@@ -53,8 +54,11 @@ func ArgRepeatPatterns(token, anio, anioCorto string) []string {
 		add(token + anioCorto + anio)        // juan901990
 	}
 
-	// Patrón "token + año actual repetido" (muy visto en 2023-2025)
-	for _, yr := range []string{"2023", "2024", "2025"} {
+	// Patrón "token + año actual repetido": los últimos 3 años hasta el
+	// de referencia (ver utils.ReferenceDate)
+	ref := utils.ReferenceYear()
+	for y := ref - 2; y <= ref; y++ {
+		yr := strconv.Itoa(y)
 		add(token + yr + yr)
 		add(token + yr[2:] + yr[2:]) // token + 2324
 	}
@@ -139,150 +143,65 @@ var ArgCommonPhrases = []string{
 	// NombreCapitalized + año + !
 }
 
-// GenerateArgPatterns genera candidatos específicos del contexto argentino.
-// Se llama desde RunProfiler después de GenerateFromProfile para agregar
-// el vocabulario local sin duplicar la lógica base.
-func GenerateArgPatterns(p Profile) []string {
-	seen := make(map[string]bool)
-	var result []string
-
-	add := func(s string) {
-		s = trimAndCheck(s)
-		if s == "" || seen[s] {
-			return
-		}
-		seen[s] = true
-		result = append(result, s)
-	}
-
-	n := ""
-	nc := ""
-	if p.Nombre != "" {
-		n = lowerTrim(p.Nombre)
-		nc = capFirst(n)
-	}
-
-	// ── 1. Frases locales combinadas con el nombre ────────────────
-	for _, phrase := range ArgCommonPhrases {
-		add(phrase)
-		if n != "" {
-			add(n + phrase)
-			add(nc + phrase)
-			add(phrase + n)
-			add(phrase + nc)
-		}
-		if p.Anio != "" {
-			add(phrase + p.Anio)
-			add(phrase + p.AnioCorto)
-		}
-	}
-
-	// ── 2. Clubes de fútbol de Argentina × año ────────────────────
-	clubes := []string{
-		"boca", "bocajuniors", "river", "riverplate",
-		"racing", "independiente", "sanlorenzo",
-		"huracan", "velez", "lanus", "belgrano",
-		"talleres", "estudiantes", "gimnasia",
-		"newells", "rosariocentral", "banfield",
-		"platense", "sarmiento", "tigre",
-	}
+// argClubs: clubes de fútbol de Argentina, ordenados por cantidad de hinchas (aprox.)
+var argClubs = []string{
+	"boca", "bocajuniors", "river", "riverplate",
+	"racing", "independiente", "sanlorenzo",
+	"huracan", "velez", "lanus", "belgrano",
+	"talleres", "estudiantes", "gimnasia",
+	"newells", "rosariocentral", "banfield",
+	"platense", "sarmiento", "tigre",
+}
 
-	// Si el objetivo ingresó equipo, ya está cubierto en profiler.go.
-	// Acá cubrimos los más frecuentes sin importar el equipo declarado.
-	for _, club := range clubes {
-		add(club)
-		add(capFirst(club))
-		if p.Anio != "" {
-			add(club + p.Anio)
-			add(capFirst(club) + p.Anio)
-			add(club + p.AnioCorto)
-		}
-		if n != "" {
-			add(n + club)
-			add(club + n)
-			add(nc + capFirst(club))
-		}
-		for _, num := range []string{"1", "10", "9", "11", "123"} {
-			add(club + num)
-		}
-	}
+func init() {
+	RegisterLocale(&Locale{
+		Code:              "ar",
+		Name:              "Argentina",
+		Phrases:           ArgCommonPhrases,
+		Clubs:             argClubs,
+		RepeatPatterns:    ArgRepeatPatterns,
+		LetterDuplication: ArgLetterDuplication,
+		NationalID:        streamCUIL,
+		IDTable:           argDNITable,
+		PhoneFormats:      argPhoneFormats,
+		RelatedIDs:        argRelatedIDs,
+		ValidID:           argValidID,
+		// Inmigración italiana y, en menor medida, nombres en inglés y portugués
+		NicknameLangs: []string{"es", "it", "en", "pt"},
+	})
+}
 
-	// ── 3. Patrones de año duplicado (comportamiento local) ───────
-	if n != "" && p.Anio != "" {
-		for _, pat := range ArgRepeatPatterns(n, p.Anio, p.AnioCorto) {
-			add(pat)
-		}
-		for _, pat := range ArgRepeatPatterns(nc, p.Anio, p.AnioCorto) {
-			add(pat)
-		}
-	}
-	if p.Apellido != "" {
-		aLow := lowerTrim(p.Apellido)
-		aCap := capFirst(aLow)
-		if p.Anio != "" {
-			for _, pat := range ArgRepeatPatterns(aLow, p.Anio, p.AnioCorto) {
-				add(pat)
-			}
-			for _, pat := range ArgRepeatPatterns(aCap, p.Anio, p.AnioCorto) {
-				add(pat)
-			}
+// streamCUIL: el CUIL está relacionado con el DNI y es muy usado como contraseña.
+// Formato CUIL: 20-XXXXXXXX-N (el DNI va en el medio, ver cuil.go)
+// La gente a veces usa su CUIL completo o parcial como contraseña.
+func streamCUIL(p Profile, add Sink) {
+	doc := cleanDocument(p.DNI)
+
+	// Si se cargó un CUIL/CUIT completo (también de empresa: 30/33/34)
+	// se usa tal cual y el DNI se toma del medio
+	if ValidCUIL(doc) {
+		add(doc, weightDNI*0.8)
+		add(doc[:2]+"-"+doc[2:10]+"-"+doc[10:], weightDNI*0.5)
+		if doc[0] == '3' {
+			return
 		}
+		doc = doc[2:10]
 	}
 
-	// ── 4. Duplicación de letras y puntuación local ───────────────
-	for _, base := range []string{n, nc} {
-		if base == "" {
-			continue
-		}
-		for _, pat := range ArgLetterDuplication(base) {
-			add(pat)
-		}
-	}
-	if p.Apellido != "" {
-		for _, base := range []string{lowerTrim(p.Apellido), capFirst(lowerTrim(p.Apellido))} {
-			for _, pat := range ArgLetterDuplication(base) {
-				add(pat)
-			}
-		}
+	dni, err := strconv.Atoi(doc)
+	if err != nil || dni <= 0 {
+		return
 	}
-
-	// ── 5. CUIL: relacionado con el DNI, muy usado como contraseña ─
-	// Formato CUIL: 20-XXXXXXXD-N (el DNI va en el medio)
-	// La gente a veces usa su CUIL completo o parcial como contraseña.
-	if p.DNI != "" {
-		dniClean := p.DNI
-		for _, r := range []string{".", "-", " "} {
-			dniClean = strings.ReplaceAll(dniClean, r, "")
-		}
-		// Prefijos CUIL más comunes para ciudadanos argentinos
-		for _, prefix := range []string{"20", "23", "24", "27"} {
-			// No conocemos el dígito verificador, generamos los posibles (0-9)
-			for d := 0; d <= 9; d++ {
-				cuil := prefix + dniClean + strconv.Itoa(d)
-				add(cuil)
-				add(prefix + "-" + dniClean + "-" + strconv.Itoa(d))
-			}
+	// Prefijos CUIL de persona física, del más al menos común.
+	// Con el verificador real hay un solo CUIL por prefijo.
+	for k, prefix := range CUILPrefixes {
+		pre, dv, err := CUIL(prefix, dni)
+		if err != nil {
+			continue // ese prefijo no tiene CUIL para este DNI
 		}
+		w := weightDNI * 0.5 * freq(k)
+		compact, dashed := FormatCUIL(pre, dni, dv)
+		add(compact, w)
+		add(dashed, w*0.6)
 	}
-
-	return result
-}
-
-// ── helpers locales ───────────────────────────────────────────────
-
-func lowerTrim(s string) string {
-	return strings.ToLower(strings.TrimSpace(s))
-}
-
-func capFirst(s string) string {
-	return transforms.Capitalize(s)
-}
-
-func trimAndCheck(s string) string {
-	s = strings.TrimSpace(s)
-	l := len([]rune(s))
-	if l < 4 || l > 28 {
-		return ""
-	}
-	return s
 }
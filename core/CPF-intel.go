@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ================================================================
+// MÓDULO: CPF BRASIL
+//
+// El CPF son 9 dígitos de base más 2 dígitos verificadores módulo 11:
+//
+//   DV1 = 11 - (Σ dígito_i × (10-i)) mod 11  sobre los 9 de base
+//   DV2 = 11 - (Σ dígito_i × (11-i)) mod 11  sobre los 9 de base + DV1
+//   (si el resto es 0 o 1 el verificador es 0)
+//
+// Se escribe 123.456.789-09 o pegado 12345678909; como PIN se usan los
+// últimos 4 o 6 dígitos. Con un CPF conocido o solo la base (o parte de
+// ella, que se completa con ceros a la izquierda) se recalculan los
+// verificadores y se combinan las formas con nombre, apellido y año.
+// ================================================================
+
+// CPFCheckDigits calcula los dos dígitos verificadores de una base de 9 dígitos.
+func CPFCheckDigits(base string) (string, error) {
+	if len(base) != 9 {
+		return "", fmt.Errorf("CPF inválido: la base %q debe tener 9 dígitos", base)
+	}
+	if _, err := strconv.Atoi(base); err != nil {
+		return "", fmt.Errorf("CPF inválido: %q", base)
+	}
+	d1 := cpfDigit(base)
+	return d1 + cpfDigit(base+d1), nil
+}
+
+// cpfDigit calcula un verificador: pesos desde len+1 hasta 2.
+func cpfDigit(digits string) string {
+	sum := 0
+	for i, r := range digits {
+		sum += int(r-'0') * (len(digits) + 1 - i)
+	}
+	if r := sum % 11; r >= 2 {
+		return strconv.Itoa(11 - r)
+	}
+	return "0"
+}
+
+// parseCPF extrae la base de 9 dígitos de un CPF completo (11 dígitos,
+// los verificadores se ignoran) o parcial (hasta 9, se completa con ceros).
+func parseCPF(raw string) (string, bool) {
+	digits := cleanDocument(raw)
+	if _, err := strconv.Atoi(digits); err != nil {
+		return "", false
+	}
+	switch {
+	case len(digits) == 11:
+		return digits[:9], true
+	case len(digits) > 0 && len(digits) <= 9:
+		return strings.Repeat("0", 9-len(digits)) + digits, true
+	}
+	return "", false
+}
+
+// CPFFormats genera las formas escritas de un CPF a partir de su base,
+// de la más a la menos usada: 12345678909, 123.456.789-09, la base sola,
+// los últimos 6 y 4 dígitos y 123456789-09.
+func CPFFormats(base string) []string {
+	dv, err := CPFCheckDigits(base)
+	if err != nil {
+		return nil
+	}
+	full := base + dv
+	return []string{
+		full,
+		base[0:3] + "." + base[3:6] + "." + base[6:9] + "-" + dv,
+		base,
+		full[5:],
+		full[7:],
+		base + "-" + dv,
+	}
+}
+
+// CPFVariantsFromKnown es la versión en memoria de StreamCPFVariantsFromKnown, sin duplicados.
+func CPFVariantsFromKnown(cpfStr string, nombre string, apellido string, anio string) []string {
+	return collect(func(emit Sink) { StreamCPFVariantsFromKnown(cpfStr, nombre, apellido, anio, emit) })
+}
+
+// StreamCPFVariantsFromKnown genera variantes de un CPF conocido o
+// parcial: recalcula los verificadores y combina las formas de CPFFormats
+// con nombre, apellido y año igual que StreamDNIVariantsFromKnown.
+func StreamCPFVariantsFromKnown(cpfStr string, nombre string, apellido string, anio string, emit Sink) {
+	formats := []string{cleanDocument(cpfStr)}
+	if base, ok := parseCPF(cpfStr); ok {
+		formats = CPFFormats(base)
+	}
+	streamKnownIDVariants(formats, weightDNI, nombre, apellido, anio, emit)
+}
@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestCPFCheckDigits(t *testing.T) {
+	tests := []struct {
+		base string
+		want string // "" = error
+	}{
+		{"123456789", "09"},
+		{"111444777", "35"},
+		{"529982247", "25"},
+		{"000000001", "91"},
+		{"12345678", ""},
+		{"12345678a", ""},
+	}
+	for _, tt := range tests {
+		got, err := CPFCheckDigits(tt.base)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("CPFCheckDigits(%q) = %s; se esperaba error", tt.base, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("CPFCheckDigits(%q) = %s, %v; se esperaba %s", tt.base, got, err, tt.want)
+		}
+	}
+}
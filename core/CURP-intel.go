@@ -0,0 +1,312 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ================================================================
+// MÓDULO: CURP / RFC MÉXICO
+//
+// Tanto la CURP como el RFC de una persona física se arman casi por
+// completo con datos que ya tiene el perfil:
+//
+//   CURP  GOPC900315HDFMRR09   (18)
+//         GOPC    1.ª letra y 1.ª vocal interna del primer apellido,
+//                 inicial del segundo apellido (X si no tiene), inicial del nombre
+//         900315  fecha AAMMDD
+//         H       sexo (H/M)
+//         DF      entidad de nacimiento (NE = nacido en el extranjero)
+//         MRR     1.ª consonante interna de apellido, segundo apellido y nombre
+//         0       homoclave: 0-9 nacidos antes de 2000, A-Z desde 2000
+//         9       dígito verificador
+//
+//   RFC   GOPC900315AB3        (13)
+//         GOPC900315 igual que la CURP; homoclave de 2 caracteres que el SAT
+//         calcula con el nombre completo y dígito verificador módulo 11.
+//
+// En contraseñas aparecen completas, truncadas a 10 caracteres (la parte
+// sin homoclave, que es la que la gente recuerda) y en minúsculas.
+// ================================================================
+
+// curpStates: código de entidad federativa de la CURP por nombre normalizado.
+var curpStates = map[string]string{
+	"aguascalientes": "AS", "baja california": "BC", "baja california sur": "BS",
+	"campeche": "CC", "coahuila": "CL", "colima": "CM", "chiapas": "CS",
+	"chihuahua": "CH", "ciudad de mexico": "DF", "cdmx": "DF", "distrito federal": "DF",
+	"durango": "DG", "guanajuato": "GT", "guerrero": "GR", "hidalgo": "HG",
+	"jalisco": "JC", "estado de mexico": "MC", "mexico": "MC", "edomex": "MC",
+	"michoacan": "MN", "morelos": "MS", "nayarit": "NT", "nuevo leon": "NL",
+	"oaxaca": "OC", "puebla": "PL", "queretaro": "QT", "quintana roo": "QR",
+	"san luis potosi": "SP", "sinaloa": "SL", "sonora": "SR", "tabasco": "TC",
+	"tamaulipas": "TS", "tlaxcala": "TL", "veracruz": "VZ", "yucatan": "YN",
+	"zacatecas": "ZS", "extranjero": "NE",
+}
+
+// curpTopStates: entidades con más nacimientos, para cuando el perfil no
+// indica la entidad de nacimiento.
+var curpTopStates = []string{"MC", "DF", "JC", "VZ", "PL"}
+
+// curpParticles: partículas que no se toman en cuenta en nombres y apellidos.
+var curpParticles = map[string]bool{
+	"DA": true, "DAS": true, "DE": true, "DEL": true, "DER": true, "DI": true,
+	"DIE": true, "DD": true, "EL": true, "LA": true, "LAS": true, "LE": true,
+	"LES": true, "LOS": true, "MAC": true, "MC": true, "VAN": true, "VON": true, "Y": true,
+}
+
+// curpBadWords: palabras altisonantes que la RENAPO evita reemplazando la
+// segunda letra por X (lista parcial con las más comunes).
+var curpBadWords = map[string]bool{
+	"BACA": true, "BAKA": true, "BUEI": true, "BUEY": true, "CACA": true, "CACO": true,
+	"CAGA": true, "CAGO": true, "CAKA": true, "CAKO": true, "COGE": true, "COGI": true,
+	"COJA": true, "COJE": true, "COJI": true, "COJO": true, "COLA": true, "CULO": true,
+	"FALO": true, "FETO": true, "GETA": true, "GUEI": true, "GUEY": true, "JETA": true,
+	"JOTO": true, "KACA": true, "KACO": true, "KAGA": true, "KAGO": true, "KAKA": true,
+	"KAKO": true, "KOGE": true, "KOGI": true, "KOJA": true, "KOJE": true, "KOJI": true,
+	"KOJO": true, "KOLA": true, "KULO": true, "LILO": true, "LOCA": true, "LOCO": true,
+	"LOKA": true, "LOKO": true, "MAME": true, "MAMO": true, "MEAR": true, "MEAS": true,
+	"MEON": true, "MIAR": true, "MION": true, "MOCO": true, "MOKO": true, "MULA": true,
+	"MULO": true, "NACA": true, "NACO": true, "PEDA": true, "PEDO": true, "PENE": true,
+	"PIPI": true, "PITO": true, "POPO": true, "PUTA": true, "PUTO": true, "QULO": true,
+	"RATA": true, "ROBA": true, "ROBE": true, "ROBO": true, "RUIN": true, "SENO": true,
+	"TETA": true, "VACA": true, "VAGA": true, "VAGO": true, "VAKA": true, "VUEI": true,
+	"VUEY": true, "WUEI": true, "WUEY": true,
+}
+
+var curpAccents = strings.NewReplacer(
+	"Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", "Ü", "U",
+	"á", "A", "é", "E", "í", "I", "ó", "O", "ú", "U", "ü", "U", "ñ", "Ñ",
+)
+
+// normalizeMXName pasa a mayúsculas, quita acentos y descarta partículas.
+// La Ñ se conserva: la CURP la reemplaza por X, el RFC la usa en la homoclave.
+func normalizeMXName(s string) []string {
+	var words []string
+	for _, w := range strings.Fields(strings.ToUpper(curpAccents.Replace(s))) {
+		if !curpParticles[w] {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// mxGivenName elige el nombre que se usa en la clave: en nombres
+// compuestos con José o María se toma el segundo.
+func mxGivenName(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	switch words[0] {
+	case "JOSE", "J", "MARIA", "MA", "MA.", "M":
+		if len(words) > 1 {
+			return words[1]
+		}
+	}
+	return words[0]
+}
+
+func isMXVowel(r byte) bool { return strings.IndexByte("AEIOU", r) >= 0 }
+
+// mxInternal devuelve la primera vocal (vowel=true) o consonante interna
+// de word, sin contar la primera letra; X si no hay.
+func mxInternal(word string, vowel bool) string {
+	for i := 1; i < len(word); i++ {
+		c := word[i]
+		if c < 'A' || c > 'Z' {
+			continue
+		}
+		if isMXVowel(c) == vowel {
+			return string(c)
+		}
+	}
+	return "X"
+}
+
+// mxInitial devuelve la primera letra de word (vacío → X).
+func mxInitial(word string) string {
+	if word == "" || word[0] < 'A' || word[0] > 'Z' {
+		return "X"
+	}
+	return word[:1]
+}
+
+// mxKeyParts calcula las 4 letras iniciales y las 3 consonantes internas
+// comunes a CURP y RFC.
+func mxKeyParts(apellido, segundo, nombre string) (letters, consonants string) {
+	// En la clave la Ñ se escribe X
+	ap := strings.ReplaceAll(strings.Join(normalizeMXName(apellido), ""), "Ñ", "X")
+	am := strings.ReplaceAll(strings.Join(normalizeMXName(segundo), ""), "Ñ", "X")
+	nm := strings.ReplaceAll(mxGivenName(normalizeMXName(nombre)), "Ñ", "X")
+
+	letters = mxInitial(ap) + mxInternal(ap, true) + mxInitial(am) + mxInitial(nm)
+	if curpBadWords[letters] {
+		letters = letters[:1] + "X" + letters[2:]
+	}
+	consonants = mxInternal(ap, false) + mxInternal(am, false) + mxInternal(nm, false)
+	return letters, consonants
+}
+
+// CURPCheckDigit calcula el dígito verificador de los primeros 17 caracteres de una CURP.
+func CURPCheckDigit(curp17 string) string {
+	sum := 0
+	for i, r := range []rune(curp17) {
+		sum += dictValue("0123456789ABCDEFGHIJKLMNÑOPQRSTUVWXYZ", r) * (18 - i)
+	}
+	return strconv.Itoa((10 - sum%10) % 10)
+}
+
+// CURPCandidates arma las CURP probables. sexo es "H" o "M" (otro valor
+// = ambos) y estado un código o nombre de entidad ("" = las más pobladas).
+// La homoclave la asigna la RENAPO por orden de registro, así que se
+// prueban las primeras.
+func CURPCandidates(nombre, apellido, segundo, fecha, sexo, estado string) []string {
+	if len(fecha) != 8 || nombre == "" || apellido == "" {
+		return nil
+	}
+	letters, consonants := mxKeyParts(apellido, segundo, nombre)
+	date := fecha[6:8] + fecha[2:4] + fecha[0:2]
+
+	sexes := []string{"H", "M"}
+	if s := strings.ToUpper(strings.TrimSpace(sexo)); s == "H" || s == "M" {
+		sexes = []string{s}
+	}
+	states := curpTopStates
+	if code := curpStateCode(estado); code != "" {
+		states = []string{code}
+	}
+	homoclaves := "0123"
+	if fecha[4:6] >= "20" {
+		homoclaves = "ABCD"
+	}
+
+	var curps []string
+	for _, hc := range homoclaves {
+		for _, st := range states {
+			for _, sx := range sexes {
+				c17 := letters + date + sx + st + consonants + string(hc)
+				curps = append(curps, c17+CURPCheckDigit(c17))
+			}
+		}
+	}
+	return curps
+}
+
+// curpStateCode resuelve un código (DF) o nombre (Ciudad de México) de entidad.
+func curpStateCode(estado string) string {
+	e := strings.ToUpper(strings.TrimSpace(estado))
+	if len(e) == 2 {
+		for _, code := range curpStates {
+			if code == e {
+				return code
+			}
+		}
+	}
+	return curpStates[strings.ToLower(curpAccents.Replace(strings.TrimSpace(estado)))]
+}
+
+// RFC calcula el RFC de persona física (13 caracteres) con la homoclave
+// y el dígito verificador del SAT. fecha es DDMMAAAA.
+func RFC(nombre, apellido, segundo, fecha string) string {
+	if len(fecha) != 8 || nombre == "" || apellido == "" {
+		return ""
+	}
+	letters, _ := mxKeyParts(apellido, segundo, nombre)
+	rfc12 := letters + fecha[6:8] + fecha[2:4] + fecha[0:2] + rfcHomoclave(nombre, apellido, segundo)
+	return rfc12 + rfcCheckDigit(rfc12)
+}
+
+// rfcHomoclave: cada carácter del nombre completo se pasa a dos dígitos,
+// se suman los productos de cada par consecutivo por su segundo dígito y
+// las tres últimas cifras, divididas por 34, indexan la tabla del SAT.
+func rfcHomoclave(nombre, apellido, segundo string) string {
+	full := strings.Join(append(append(normalizeMXName(apellido), normalizeMXName(segundo)...), normalizeMXName(nombre)...), " ")
+
+	digits := "0"
+	for _, r := range full {
+		switch {
+		case r == ' ':
+			digits += "00"
+		case r >= '0' && r <= '9':
+			digits += "0" + string(r)
+		case r == '&':
+			digits += "10"
+		case r == 'Ñ':
+			digits += "40"
+		case r >= 'A' && r <= 'I':
+			digits += strconv.Itoa(11 + int(r-'A'))
+		case r >= 'J' && r <= 'R':
+			digits += strconv.Itoa(21 + int(r-'J'))
+		case r >= 'S' && r <= 'Z':
+			digits += strconv.Itoa(32 + int(r-'S'))
+		}
+	}
+
+	sum := 0
+	for i := 0; i+1 < len(digits); i++ {
+		pair, _ := strconv.Atoi(digits[i : i+2])
+		sum += pair * int(digits[i+1]-'0')
+	}
+	const table = "123456789ABCDEFGHIJKLMNPQRSTUVWXYZ"
+	last := sum % 1000
+	return string(table[last/34]) + string(table[last%34])
+}
+
+// rfcCheckDigit calcula el dígito verificador módulo 11 de los 12 primeros caracteres.
+func rfcCheckDigit(rfc12 string) string {
+	runes := []rune(rfc12)
+	sum := 0
+	for i, r := range runes {
+		sum += dictValue("0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ", r) * (len(runes) + 1 - i)
+	}
+	switch r := sum % 11; r {
+	case 0:
+		return "0"
+	case 1:
+		return "A"
+	default:
+		return strconv.Itoa(11 - r)
+	}
+}
+
+// dictValue devuelve la posición de r en dict contada en runas (0 si no está).
+func dictValue(dict string, r rune) int {
+	for i, d := range []rune(dict) {
+		if d == r {
+			return i
+		}
+	}
+	return 0
+}
+
+// streamCURPRFC genera RFC y CURP derivados del perfil, completos,
+// truncados y en minúsculas. Si el documento del perfil ya es una CURP o
+// un RFC, se usa tal cual en lugar de los estimados.
+func streamCURPRFC(p Profile, add Sink) {
+	doc := strings.ToUpper(cleanDocument(p.DNI))
+	rfc := RFC(p.Nombre, p.Apellido, p.SegundoApellido, p.FechaNacimiento)
+	curps := CURPCandidates(p.Nombre, p.Apellido, p.SegundoApellido, p.FechaNacimiento, p.Sexo, p.EstadoNacimiento)
+	switch len(doc) {
+	case 18:
+		curps = []string{doc}
+	case 13:
+		rfc = doc
+	}
+
+	w := weightDNI
+	if rfc != "" {
+		for k, f := range []string{rfc[:10], rfc, rfc[:10] + "-" + rfc[10:]} {
+			add(f, w*freq(k))
+			add(strings.ToLower(f), w*0.8*freq(k))
+		}
+	}
+	for k, curp := range curps {
+		cw := w * 0.8 * freq(k)
+		add(curp, cw)
+		add(strings.ToLower(curp), cw*0.8)
+		add(curp[:16], cw*0.5)
+		if k == 0 && rfc == "" {
+			add(curp[:10], w)
+			add(strings.ToLower(curp[:10]), w*0.8)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package core
+
+import "testing"
+
+func TestCURPCheckDigit(t *testing.T) {
+	for _, curp := range []string{
+		"MAHJ280603MSPRRV09",
+		"HEGG560427MVZRRL04",
+	} {
+		if got := CURPCheckDigit(curp[:17]); got != curp[17:] {
+			t.Errorf("CURPCheckDigit(%s) = %s, se esperaba %s", curp[:17], got, curp[17:])
+		}
+	}
+}
+
+func TestRFC(t *testing.T) {
+	tests := []struct {
+		nombre, apellido, segundo, fecha string
+		want                             string
+	}{
+		{"Juan", "Barrios", "Fernández", "13121970", "BAFJ701213SBA"},
+		{"Juan", "Barrios", "Fernández", "1970", ""},
+		{"", "Barrios", "Fernández", "13121970", ""},
+	}
+	for _, tt := range tests {
+		if got := RFC(tt.nombre, tt.apellido, tt.segundo, tt.fecha); got != tt.want {
+			t.Errorf("RFC(%s %s %s, %s) = %q, se esperaba %q", tt.nombre, tt.apellido, tt.segundo, tt.fecha, got, tt.want)
+		}
+	}
+}
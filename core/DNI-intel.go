@@ -1,6 +1,7 @@
 package core
 
 import (
+	_ "embed"
 	"fmt"
 	"strings"
 	"trickster/transforms"
@@ -26,41 +27,18 @@ import (
 //   - Reemisión por pérdida/daño
 //   - Aceleración demográfica post-2012 (digitalización masiva)
 //
-// El generador produce el rango probable ±margen, formateado como lo
-// escribe la gente en contraseñas: con y sin puntos, con y sin ceros iniciales.
+// La correlación por año (mediana y dispersión) está en data/dni_ar.csv.
+// El generador concentra los candidatos alrededor de la mediana (ver
+// idtable.go) y los formatea como los escribe la gente en contraseñas:
+// con y sin puntos, con y sin ceros iniciales.
 // ================================================================
 
-// dniRangeForBirthYear devuelve el rango [min, max] de DNI probable
-// para una persona nacida en el año dado.
-// Margen amplio para cubrir tardíos y excepciones.
-func dniRangeForBirthYear(birthYear int) (min, max int) {
-	switch {
-	case birthYear <= 1949:
-		return 1_000_000, 4_999_999
-	case birthYear <= 1959:
-		return 4_000_000, 9_999_999
-	case birthYear <= 1969:
-		return 9_000_000, 19_999_999
-	case birthYear <= 1979:
-		return 18_000_000, 29_999_999
-	case birthYear <= 1984:
-		return 27_000_000, 34_999_999
-	case birthYear <= 1989:
-		return 33_000_000, 39_999_999
-	case birthYear <= 1994:
-		return 38_000_000, 45_999_999
-	case birthYear <= 1999:
-		return 44_000_000, 51_999_999
-	case birthYear <= 2004:
-		return 50_000_000, 57_999_999
-	case birthYear <= 2009:
-		return 55_000_000, 59_999_999
-	case birthYear <= 2015:
-		return 70_000_000, 74_999_999
-	default:
-		return 73_000_000, 79_999_999
-	}
-}
+//go:embed data/dni_ar.csv
+var dniTableAR string
+
+// argDNITable es la tabla año → DNI de data/dni_ar.csv (mediana y
+// dispersión por año de nacimiento).
+var argDNITable = mustParseIDTable(dniTableAR)
 
 // DNIFormats genera todas las formas en que una persona escribe su DNI
 // en una contraseña: con/sin puntos, con/sin espacios, compacto.
@@ -122,75 +100,107 @@ func formatDNIWithDashes(raw string) string {
 	return raw
 }
 
-// GenerateDNICandidates genera candidatos de DNI para el año de nacimiento dado.
-// Produce una wordlist de DNIs probables en todos los formatos relevantes,
-// más combinaciones con el nombre si se provee.
-//
-// step controla la densidad: step=1000 produce ~1000 candidatos por millón de rango,
-// step=5000 produce ~200 por millón (más rápido, menos exhaustivo).
+// dniBlockSize es la cantidad de DNIs que procesa cada tarea del rango.
+const dniBlockSize = 256
+
+// GenerateDNICandidates es la versión en memoria de StreamDNICandidates, sin duplicados.
 func GenerateDNICandidates(birthYear int, nombre string, step int) []string {
+	return collect(func(emit Sink) { StreamDNICandidates(birthYear, nombre, step, 0, emit) })
+}
+
+// StreamDNICandidates genera candidatos de DNI para el año de nacimiento dado.
+// Produce DNIs probables en todos los formatos relevantes, más combinaciones
+// con el nombre si se provee, enviándolos a emit sin acumularlos.
+//
+// step controla la cantidad: la misma que un recorrido de a step del rango
+// (step=1000 produce ~1000 candidatos por millón, step=5000 ~200), pero
+// más densos cerca de la mediana del año y más espaciados en las colas.
+func StreamDNICandidates(birthYear int, nombre string, step int, workers int, emit Sink) {
+	streamIDTable(argDNITable, birthYear, DNIFormats, argRelatedIDs, nombre, step, workers, emit)
+}
+
+// streamIDTable toma los documentos de t según su densidad (ver
+// IDTable.IDSamples); los más cercanos a la mediana pesan más.
+func streamIDTable(t *IDTable, birthYear int, formats, related func(doc int) []string, nombre string, step int, workers int, emit Sink) {
+	docs, density := t.IDSamples(birthYear, step)
+	streamIDs(len(docs), func(i int) (int, float64) { return docs[i], density[i] },
+		formats, related, nombre, workers, emit)
+}
+
+// streamDNIRange recorre [min, max] de a step documentos, todos con el
+// mismo peso. Lo usan los packs que solo tienen un rango por año (ver
+// Locale.IDRange).
+func streamDNIRange(min, max int, formats, related func(doc int) []string, nombre string, step int, workers int, emit Sink) {
 	if step <= 0 {
 		step = 1000
 	}
+	streamIDs((max-min)/step+1, func(i int) (int, float64) { return min + i*step, 1 },
+		formats, related, nombre, workers, emit)
+}
 
-	min, max := dniRangeForBirthYear(birthYear)
-	seen := make(map[string]bool)
-	var result []string
-
-	add := func(s string) {
-		s = strings.TrimSpace(s)
-		if s != "" && !seen[s] {
-			seen[s] = true
-			result = append(result, s)
+// streamIDs genera los candidatos de n documentos: doc(i) da el i-ésimo
+// número, en orden ascendente, y el factor de su peso. formats da las
+// formas escritas de cada número y related, si no es nil, los documentos
+// derivados (CUIL en Argentina).
+func streamIDs(n int, doc func(i int) (int, float64), formats, related func(doc int) []string, nombre string, workers int, emit Sink) {
+	nm := strings.ToLower(strings.TrimSpace(nombre))
+	nc := transforms.Capitalize(nm)
+
+	// Cada DNI del rango es apenas uno de miles de posibles: peso bajo,
+	// para que no desplace a los candidatos personales.
+	base := weightDNIRange
+
+	// Los documentos se parten en bloques de dniBlockSize; cada bloque es
+	// una tarea del pool y se vuelca en orden ascendente (ver parallel.go).
+	blocks := (n + dniBlockSize - 1) / dniBlockSize
+
+	runOrdered(workers, blocks, func(b int, add Sink) {
+		from := b * dniBlockSize
+		to := from + dniBlockSize
+		if to > n {
+			to = n
 		}
-	}
 
-	n := strings.ToLower(strings.TrimSpace(nombre))
-	nc := transforms.Capitalize(n)
+		for i := from; i < to; i++ {
+			dni, factor := doc(i)
+			w := base * factor
+			for k, f := range formats(dni) {
+				add(f, w*freq(k))
+
+				// DNI solo con sufijos comunes
+				add(f+"!", w*0.6*freq(k))
+				add(f+".", w*0.4*freq(k))
+
+				// Nombre + DNI (patrón muy común en Argentina)
+				if nm != "" {
+					wn := w * freq(k) * weightNombre * 0.7
+					add(nm+f, wn*wLower)
+					add(nc+f, wn*wCap)
+					add(f+nm, wn*0.5*wLower)
+					add(f+nc, wn*0.5*wCap)
+					add(nm+"."+f, wn*0.3)
+					add(nm+"_"+f, wn*0.3)
+				}
+			}
 
-	for dni := min; dni <= max; dni += step {
-		for _, f := range DNIFormats(dni) {
-			add(f)
-
-			// DNI solo con sufijos comunes
-			add(f + "!")
-			add(f + ".")
-
-			// Nombre + DNI (patrón muy común en Argentina)
-			if n != "" {
-				add(n + f)
-				add(nc + f)
-				add(f + n)
-				add(f + nc)
-				add(n + "." + f)
-				add(n + "_" + f)
+			// Documentos derivados, ej: CUIL válidos (uno por prefijo, no diez)
+			if related != nil {
+				for k, id := range related(dni) {
+					add(id, w*0.5*freq(k))
+				}
 			}
 		}
-	}
-
-	return result
+	}, emit)
 }
 
-// DNIVariantsFromKnown genera variantes cuando el DNI ya se conoce exactamente.
-// Más exhaustivo que GenerateDNICandidates porque el DNI real ya está dado.
+// DNIVariantsFromKnown es la versión en memoria de StreamDNIVariantsFromKnown, sin duplicados.
 func DNIVariantsFromKnown(dniStr string, nombre string, apellido string, anio string) []string {
-	seen := make(map[string]bool)
-	var result []string
-
-	add := func(s string) {
-		s = strings.TrimSpace(s)
-		if s == "" || seen[s] {
-			return
-		}
-		seen[s] = true
-		result = append(result, s)
-	}
-
-	n := strings.ToLower(strings.TrimSpace(nombre))
-	nc := transforms.Capitalize(n)
-	a := strings.ToLower(strings.TrimSpace(apellido))
-	ac := transforms.Capitalize(a)
+	return collect(func(emit Sink) { StreamDNIVariantsFromKnown(dniStr, nombre, apellido, anio, emit) })
+}
 
+// StreamDNIVariantsFromKnown genera variantes cuando el DNI ya se conoce exactamente.
+// Más exhaustivo que StreamDNICandidates porque el DNI real ya está dado.
+func StreamDNIVariantsFromKnown(dniStr string, nombre string, apellido string, anio string, emit Sink) {
 	// Normalizar el DNI: quitar puntos y guiones
 	dniClean := strings.ReplaceAll(dniStr, ".", "")
 	dniClean = strings.ReplaceAll(dniClean, "-", "")
@@ -206,49 +216,66 @@ func DNIVariantsFromKnown(dniStr string, nombre string, apellido string, anio st
 		dniFormats = []string{dniClean}
 	}
 
-	for _, f := range dniFormats {
-		add(f)
+	streamKnownIDVariants(dniFormats, weightDNI, nombre, apellido, anio, emit)
+}
+
+// streamKnownIDVariants combina las formas escritas de un número
+// conocido (DNI, RUT, teléfono...) con sufijos, nombre, apellido y año.
+// formats va de la forma más usada a la menos usada y base es el peso
+// del campo de origen (weightDNI, weightPhone).
+func streamKnownIDVariants(formats []string, base float64, nombre string, apellido string, anio string, emit Sink) {
+	add := emit // longitud y duplicados se filtran en CandidateSet
+
+	n := strings.ToLower(strings.TrimSpace(nombre))
+	nc := transforms.Capitalize(n)
+	a := strings.ToLower(strings.TrimSpace(apellido))
+	ac := transforms.Capitalize(a)
+
+	for k, f := range formats {
+		w := base * freq(k)
+		add(f, w)
 
 		// DNI + sufijos
-		for _, suf := range []string{"!", "@", "#", ".", "1", "12", "123"} {
-			add(f + suf)
+		for j, suf := range []string{"!", "@", "#", ".", "1", "12", "123"} {
+			add(f+suf, w*0.7*freq(j))
 		}
 
 		// Nombre + DNI
 		if n != "" {
-			add(n + f)
-			add(nc + f)
-			add(f + n)
-			add(f + nc)
-			add(n + "." + f)
-			add(n + "_" + f)
-			add(nc + "." + f)
+			wn := w * weightNombre * 0.7
+			add(n+f, wn*wLower)
+			add(nc+f, wn*wCap)
+			add(f+n, wn*0.5*wLower)
+			add(f+nc, wn*0.5*wCap)
+			add(n+"."+f, wn*0.3)
+			add(n+"_"+f, wn*0.3)
+			add(nc+"."+f, wn*0.3*wCap)
 		}
 
 		// Apellido + DNI
 		if a != "" {
-			add(a + f)
-			add(ac + f)
-			add(f + a)
+			wa := w * weightApellido * 0.7
+			add(a+f, wa*wLower)
+			add(ac+f, wa*wCap)
+			add(f+a, wa*0.5)
 		}
 
 		// DNI + año
 		if anio != "" {
-			add(f + anio)
-			add(anio + f)
-			add(f + anio[2:]) // DNI + año corto
+			add(f+anio, w*weightAnio*0.6)
+			add(anio+f, w*weightAnio*0.3)
+			add(f+anio[2:], w*weightAnio*0.5) // DNI + año corto
 		}
 
 		// Nombre + DNI + sufijo
 		if n != "" {
-			for _, suf := range []string{"!", "@", "1", "123"} {
-				add(n + f + suf)
-				add(nc + f + suf)
+			for j, suf := range []string{"!", "@", "1", "123"} {
+				wn := w * weightNombre * 0.5 * freq(j)
+				add(n+f+suf, wn*wLower)
+				add(nc+f+suf, wn*wCap)
 			}
 		}
 	}
-
-	return result
 }
 
 
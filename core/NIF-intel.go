@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ================================================================
+// MÓDULO: DNI / NIE ESPAÑA
+//
+// El DNI español son 8 dígitos (con ceros a la izquierda) más una letra
+// de control: el número módulo 23 indexa la tabla TRWAGMYFPDXBNJZSQVHLCKE.
+// El NIE de extranjeros empieza con X, Y o Z seguida de 7 dígitos; para
+// la letra se reemplaza X=0, Y=1, Z=2 y se calcula igual.
+//
+// Con un documento conocido o parcial (sin letra, con la letra mal
+// escrita) se recalcula la letra correcta y se generan las formas en que
+// se escribe: 12345678Z, 12345678z, 12345678-Z, 12.345.678-Z...
+// ================================================================
+
+const nifLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+// NIFLetter devuelve la letra de control del DNI español para number.
+func NIFLetter(number int) string {
+	return string(nifLetters[number%23])
+}
+
+// NIELetter devuelve la letra de control de un NIE (prefix X, Y o Z).
+func NIELetter(prefix string, number int) (string, error) {
+	i := strings.Index("XYZ", strings.ToUpper(prefix))
+	if len(prefix) != 1 || i < 0 || number < 0 || number > 9_999_999 {
+		return "", fmt.Errorf("NIE inválido: prefijo %q, número %d", prefix, number)
+	}
+	return NIFLetter(i*10_000_000 + number), nil
+}
+
+// parseNIF separa un DNI o NIE escrito de cualquier forma en prefijo de
+// NIE ("" para DNI) y número. La letra final, si está, se ignora: siempre
+// se recalcula.
+func parseNIF(raw string) (prefix string, number int, ok bool) {
+	clean := strings.ToUpper(cleanDocument(strings.TrimSpace(raw)))
+	if clean != "" && strings.ContainsRune("XYZ", rune(clean[0])) {
+		prefix, clean = clean[:1], clean[1:]
+	}
+	if n := len(clean); n > 0 && clean[n-1] >= 'A' && clean[n-1] <= 'Z' {
+		clean = clean[:n-1]
+	}
+	maxLen := 8
+	if prefix != "" {
+		maxLen = 7
+	}
+	if clean == "" || len(clean) > maxLen {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(clean)
+	if err != nil || number < 0 {
+		return "", 0, false
+	}
+	return prefix, number, true
+}
+
+// NIFFormats genera las formas escritas de un DNI español, de la más a la
+// menos usada: 12345678Z, 12345678z, 12345678-Z, 12345678-z,
+// 12.345.678-Z, el número solo y, si empieza con ceros, sin ellos.
+func NIFFormats(number int) []string {
+	body := fmt.Sprintf("%08d", number)
+	return nifForms(body, dottedThousands(body), NIFLetter(number), trimLeadingZeros(body))
+}
+
+// NIEFormats es NIFFormats para un NIE: X1234567L, x1234567l, X-1234567-L...
+func NIEFormats(prefix string, number int) []string {
+	letter, err := NIELetter(prefix, number)
+	if err != nil {
+		return nil
+	}
+	pre := strings.ToUpper(prefix)
+	digits := fmt.Sprintf("%07d", number)
+	forms := nifForms(pre+digits, pre+"-"+digits, letter, pre+digits)
+	return append(forms, strings.ToLower(pre)+digits+strings.ToLower(letter))
+}
+
+// nifForms combina cuerpo, cuerpo con separadores y letra en mayúscula y
+// minúscula, con y sin guion.
+func nifForms(body, separated, letter, bare string) []string {
+	lower := strings.ToLower(letter)
+	forms := []string{
+		body + letter,
+		body + lower,
+		body + "-" + letter,
+		body + "-" + lower,
+		separated + "-" + letter,
+		body,
+	}
+	if bare != body {
+		forms = append(forms, bare+letter)
+	}
+	return forms
+}
+
+// NIFVariantsFromKnown es la versión en memoria de StreamNIFVariantsFromKnown, sin duplicados.
+func NIFVariantsFromKnown(doc string, nombre string, apellido string, anio string) []string {
+	return collect(func(emit Sink) { StreamNIFVariantsFromKnown(doc, nombre, apellido, anio, emit) })
+}
+
+// StreamNIFVariantsFromKnown genera variantes de un DNI o NIE español
+// conocido o parcial: recalcula la letra y combina sus formas escritas con
+// nombre, apellido y año igual que StreamDNIVariantsFromKnown.
+func StreamNIFVariantsFromKnown(doc string, nombre string, apellido string, anio string, emit Sink) {
+	formats := []string{cleanDocument(doc)}
+	if prefix, number, ok := parseNIF(doc); ok {
+		if prefix == "" {
+			formats = NIFFormats(number)
+		} else {
+			formats = NIEFormats(prefix, number)
+		}
+	}
+	streamKnownIDVariants(formats, weightDNI, nombre, apellido, anio, emit)
+}
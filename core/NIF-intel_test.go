@@ -0,0 +1,46 @@
+package core
+
+import "testing"
+
+func TestNIFLetter(t *testing.T) {
+	tests := []struct {
+		number int
+		want   string
+	}{
+		{12345678, "Z"},
+		{0, "T"},
+		{87654321, "X"},
+	}
+	for _, tt := range tests {
+		if got := NIFLetter(tt.number); got != tt.want {
+			t.Errorf("NIFLetter(%d) = %s, se esperaba %s", tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestNIELetter(t *testing.T) {
+	tests := []struct {
+		prefix string
+		number int
+		want   string // "" = error
+	}{
+		{"X", 1234567, "L"},
+		{"Y", 1234567, "X"},
+		{"Z", 1234567, "R"},
+		{"x", 1234567, "L"},
+		{"W", 1234567, ""},
+		{"X", 12345678, ""},
+	}
+	for _, tt := range tests {
+		got, err := NIELetter(tt.prefix, tt.number)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("NIELetter(%s, %d) = %s; se esperaba error", tt.prefix, tt.number, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NIELetter(%s, %d) = %s, %v; se esperaba %s", tt.prefix, tt.number, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ================================================================
+// MÓDULO: RUT INTELIGENTE CHILE
+//
+// El RUN/RUT chileno se asigna al inscribir el nacimiento en el
+// Registro Civil y es correlativo a nivel nacional, así que, igual que
+// el DNI argentino (DNI-intel.go), el número se puede acotar por año de
+// nacimiento (fuente: Registro Civil + datos públicos):
+//
+//   Nacidos ~1940-1949 → RUT  2.000.000 –  5.000.000
+//   Nacidos ~1950-1959 → RUT  4.000.000 –  8.000.000
+//   Nacidos ~1960-1969 → RUT  6.000.000 – 11.000.000
+//   Nacidos ~1970-1979 → RUT  8.000.000 – 14.000.000
+//   Nacidos ~1980-1989 → RUT 12.000.000 – 17.500.000
+//   Nacidos ~1990-1999 → RUT 16.500.000 – 20.500.000
+//   Nacidos ~2000-2009 → RUT 19.500.000 – 23.500.000
+//   Nacidos ~2010-      → RUT 22.500.000 – 27.500.000
+//
+// NOTA: los márgenes son amplios por inscripciones tardías y porque las
+// personas nacidas antes de los 70 recibieron el RUN ya de adultas.
+// Los extranjeros residentes tienen RUT desde 100.000.000 y no se cubren.
+//
+// El dígito verificador es módulo 11 con pesos 2..7 desde la derecha;
+// cuando da 10 se escribe K (o k).
+// ================================================================
+
+// rutRangeForBirthYear devuelve el rango [min, max] de RUT probable
+// para una persona nacida en el año dado.
+func rutRangeForBirthYear(birthYear int) (min, max int) {
+	switch {
+	case birthYear < 1950:
+		return 2_000_000, 5_000_000
+	case birthYear < 1960:
+		return 4_000_000, 8_000_000
+	case birthYear < 1970:
+		return 6_000_000, 11_000_000
+	case birthYear < 1980:
+		return 8_000_000, 14_000_000
+	case birthYear < 1990:
+		return 12_000_000, 17_500_000
+	case birthYear < 2000:
+		return 16_500_000, 20_500_000
+	case birthYear < 2010:
+		return 19_500_000, 23_500_000
+	default:
+		return 22_500_000, 27_500_000
+	}
+}
+
+// RUTCheckDigit calcula el dígito verificador del RUT: "0"-"9" o "K".
+func RUTCheckDigit(rut int) string {
+	sum, weight := 0, 2
+	for n := rut; n > 0; n /= 10 {
+		sum += (n % 10) * weight
+		weight++
+		if weight > 7 {
+			weight = 2
+		}
+	}
+	switch dv := 11 - sum%11; dv {
+	case 11:
+		return "0"
+	case 10:
+		return "K"
+	default:
+		return strconv.Itoa(dv)
+	}
+}
+
+// RUTFormats genera las formas en que se escribe un RUT en una contraseña,
+// de la más a la menos usada: 123456789, 12345678-9, 12.345.678-9, el
+// cuerpo sin verificador y, si el verificador es K, también con k minúscula.
+func RUTFormats(rut int) []string {
+	body := strconv.Itoa(rut)
+	dotted := dottedThousands(body)
+	dv := RUTCheckDigit(rut)
+
+	forms := []string{
+		body + dv,
+		body + "-" + dv,
+		dotted + "-" + dv,
+		body,
+		dotted,
+	}
+	if dv == "K" {
+		forms = append(forms,
+			body+"k",
+			body+"-k",
+			dotted+"-k",
+		)
+	}
+	return forms
+}
+
+// parseRUT extrae el cuerpo numérico de un RUT escrito con o sin
+// verificador. Se considera que trae verificador si tiene guion, termina
+// en K o tiene 9 dígitos (ningún RUN de persona pasa de 8).
+func parseRUT(raw string) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	hasDV := strings.Contains(raw, "-")
+	clean := strings.ToUpper(cleanDocument(raw))
+	if strings.HasSuffix(clean, "K") || len(clean) == 9 {
+		hasDV = true
+	}
+	if hasDV && len(clean) > 1 {
+		clean = clean[:len(clean)-1]
+	}
+	if clean == "" || strings.IndexFunc(clean, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+		return 0, false
+	}
+	rut, err := strconv.Atoi(clean)
+	return rut, err == nil && rut > 0
+}
+
+// GenerateRUTCandidates es la versión en memoria de StreamRUTCandidates, sin duplicados.
+func GenerateRUTCandidates(birthYear int, nombre string, step int) []string {
+	return collect(func(emit Sink) { StreamRUTCandidates(birthYear, nombre, step, 0, emit) })
+}
+
+// StreamRUTCandidates genera RUT probables para el año de nacimiento dado,
+// en todas sus formas escritas y combinados con el nombre. step controla
+// la densidad igual que en StreamDNICandidates.
+func StreamRUTCandidates(birthYear int, nombre string, step int, workers int, emit Sink) {
+	min, max := rutRangeForBirthYear(birthYear)
+	streamDNIRange(min, max, RUTFormats, nil, nombre, step, workers, emit)
+}
+
+// RUTVariantsFromKnown es la versión en memoria de StreamRUTVariantsFromKnown, sin duplicados.
+func RUTVariantsFromKnown(rutStr string, nombre string, apellido string, anio string) []string {
+	return collect(func(emit Sink) { StreamRUTVariantsFromKnown(rutStr, nombre, apellido, anio, emit) })
+}
+
+// StreamRUTVariantsFromKnown genera variantes cuando el RUT ya se conoce,
+// con o sin verificador: mismas combinaciones que StreamDNIVariantsFromKnown
+// sobre las formas de RUTFormats.
+func StreamRUTVariantsFromKnown(rutStr string, nombre string, apellido string, anio string, emit Sink) {
+	formats := []string{cleanDocument(rutStr)}
+	if rut, ok := parseRUT(rutStr); ok {
+		formats = RUTFormats(rut)
+	}
+	streamKnownIDVariants(formats, weightDNI, nombre, apellido, anio, emit)
+}
@@ -0,0 +1,42 @@
+package core
+
+import "testing"
+
+func TestRUTCheckDigit(t *testing.T) {
+	tests := []struct {
+		rut  int
+		want string
+	}{
+		{12345678, "5"},
+		{11111111, "1"},
+		{22222222, "2"},
+		{6, "K"},
+	}
+	for _, tt := range tests {
+		if got := RUTCheckDigit(tt.rut); got != tt.want {
+			t.Errorf("RUTCheckDigit(%d) = %s, se esperaba %s", tt.rut, got, tt.want)
+		}
+	}
+}
+
+func TestParseRUT(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want int
+		ok   bool
+	}{
+		{"12.345.678-5", 12345678, true},
+		{"123456785", 12345678, true},
+		{"12345678", 12345678, true},
+		{"6-k", 6, true},
+		{"12.345.678-0", 12345678, true}, // el verificador se recalcula
+		{"12a45678-5", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRUT(tt.raw)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRUT(%q) = %d, %v; se esperaba %d, %v", tt.raw, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"strings"
+)
+
+// ================================================================
+// MÓDULO: TELÉFONOS
+//
+// El número de teléfono es de las contraseñas numéricas más comunes y
+// se escribe de muchas maneras. En Argentina el número nacional tiene
+// 10 dígitos: código de área sin el 0 (11 en AMBA, 3 dígitos en las
+// grandes ciudades, 4 en el resto) + número local. Para un celular:
+//
+//   desde la misma área   15 2345-6789
+//   de larga distancia    011 15 2345-6789
+//   internacional         +54 9 11 2345-6789
+//
+// Cada pack define con Locale.PhoneFormats cómo se escribe un número en
+// su país; sin eso se usan los dígitos tal cual y sus últimos 8/6/4.
+// ================================================================
+
+// argAreaCodes3: códigos de área argentinos de 3 dígitos (sin el 0).
+// El 11 es el único de 2 dígitos; el resto tiene 4.
+var argAreaCodes3 = map[string]bool{
+	"220": true, "221": true, "223": true, "230": true, "236": true, "237": true,
+	"249": true, "260": true, "261": true, "263": true, "264": true, "266": true,
+	"280": true, "291": true, "294": true, "297": true, "298": true, "299": true,
+	"341": true, "342": true, "343": true, "345": true, "348": true, "351": true,
+	"353": true, "358": true, "362": true, "364": true, "370": true, "376": true,
+	"379": true, "380": true, "381": true, "383": true, "385": true, "387": true,
+	"388": true,
+}
+
+// ArgPhone es un teléfono argentino separado en sus partes.
+type ArgPhone struct {
+	Area   string // código de área sin el 0; vacío si no se conoce
+	Number string // número local (6 a 8 dígitos)
+	Mobile bool   // celular: se marca con 15 o con 9 después del +54
+}
+
+// phoneDigits deja solo los dígitos de un teléfono escrito con espacios,
+// guiones, paréntesis o +.
+func phoneDigits(raw string) string {
+	var b strings.Builder
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ParseArgPhone interpreta un teléfono argentino escrito de cualquier
+// forma: +54 9 11 2345-6789, 011 15 2345-6789, (0341) 456-7890,
+// 15 2345-6789 o solo el número local.
+func ParseArgPhone(raw string) (ArgPhone, bool) {
+	d := phoneDigits(raw)
+	var ph ArgPhone
+
+	// Prefijo internacional: 00 54 o +54, y el 9 de celular
+	d = strings.TrimPrefix(d, "00")
+	if strings.HasPrefix(d, "54") && len(d) >= 12 {
+		d = d[2:]
+		if strings.HasPrefix(d, "9") && len(d) == 11 {
+			ph.Mobile = true
+			d = d[1:]
+		}
+	}
+	d = strings.TrimPrefix(d, "0")
+
+	switch {
+	case len(d) == 10 && strings.HasPrefix(d, "15"):
+		// 15 + número local de 8 dígitos, sin área
+		ph.Mobile, ph.Number = true, d[2:]
+		return ph, true
+	case len(d) >= 6 && len(d) <= 8:
+		ph.Number = d
+		return ph, true
+	case len(d) != 10 && len(d) != 12:
+		return ArgPhone{}, false
+	}
+
+	// Con área: 10 dígitos, o 12 si tiene el 15 después del área
+	area := d[:4]
+	if strings.HasPrefix(d, "11") {
+		area = d[:2]
+	} else if argAreaCodes3[d[:3]] {
+		area = d[:3]
+	}
+	rest := d[len(area):]
+	if len(d) == 12 {
+		if !strings.HasPrefix(rest, "15") {
+			return ArgPhone{}, false
+		}
+		ph.Mobile, rest = true, rest[2:]
+	}
+	ph.Area, ph.Number = area, rest
+	return ph, true
+}
+
+// Formats genera las formas en que se escribe el teléfono en una
+// contraseña, de la más a la menos usada.
+func (ph ArgPhone) Formats() []string {
+	n, a := ph.Number, ph.Area
+	var forms []string
+	add := func(fs ...string) { forms = append(forms, fs...) }
+
+	if a != "" {
+		add(a + n)
+	}
+	add(n)
+	if ph.Mobile {
+		add("15" + n)
+	}
+	if a != "" {
+		add("0" + a + n)
+		if ph.Mobile {
+			add("0"+a+"15"+n, "549"+a+n, "+549"+a+n)
+		} else {
+			add("54"+a+n, "+54"+a+n)
+		}
+	}
+	if ph.Mobile {
+		add("15-" + splitLocal(n))
+	}
+	add(splitLocal(n))
+	if a != "" {
+		add(a + "-" + splitLocal(n))
+	}
+	return append(forms, phoneTails(a+n)...)
+}
+
+// splitLocal escribe el número local con guion: 2345-6789, 456-7890.
+func splitLocal(n string) string {
+	if len(n) <= 4 {
+		return n
+	}
+	return n[:len(n)-4] + "-" + n[len(n)-4:]
+}
+
+// phoneTails devuelve los últimos 8, 6 y 4 dígitos, muy usados como PIN.
+func phoneTails(digits string) []string {
+	var tails []string
+	for _, k := range []int{8, 6, 4} {
+		if len(digits) > k {
+			tails = append(tails, digits[len(digits)-k:])
+		}
+	}
+	return tails
+}
+
+// argPhoneFormats es el Locale.PhoneFormats del pack argentino. Un
+// celular escrito sin 15 ni 9 (11 2345-6789) se reconoce por mobile.
+func argPhoneFormats(raw string, mobile bool) []string {
+	if ph, ok := ParseArgPhone(raw); ok {
+		ph.Mobile = ph.Mobile || mobile
+		return ph.Formats()
+	}
+	return genericPhoneFormats(raw, mobile)
+}
+
+// genericPhoneFormats: los dígitos, con + si los tenía, y las terminaciones.
+func genericPhoneFormats(raw string, _ bool) []string {
+	d := phoneDigits(raw)
+	if d == "" {
+		return nil
+	}
+	forms := []string{d}
+	if strings.HasPrefix(strings.TrimSpace(raw), "+") {
+		forms = append(forms, "+"+d)
+	}
+	return append(forms, phoneTails(d)...)
+}
+
+// PhoneVariants es la versión en memoria de StreamPhoneVariants, sin duplicados.
+func PhoneVariants(l *Locale, phone string, mobile bool, nombre string, apellido string, anio string) []string {
+	return collect(func(emit Sink) { StreamPhoneVariants(l, phone, mobile, nombre, apellido, anio, emit) })
+}
+
+// StreamPhoneVariants genera las formas escritas del teléfono según el
+// pack l (mobile indica que es un celular) y las combina con nombre,
+// apellido y año, igual que StreamDNIVariantsFromKnown con el DNI.
+func StreamPhoneVariants(l *Locale, phone string, mobile bool, nombre string, apellido string, anio string, emit Sink) {
+	formats := genericPhoneFormats(phone, mobile)
+	if l != nil && l.PhoneFormats != nil {
+		formats = l.PhoneFormats(phone, mobile)
+	}
+	streamKnownIDVariants(formats, weightPhone, nombre, apellido, anio, emit)
+}
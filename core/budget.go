@@ -0,0 +1,170 @@
+package core
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ================================================================
+// PRESUPUESTO DE CANDIDATOS (TOP-N)
+//
+// En auditorías online o con rate limiting solo se pueden probar unos
+// pocos miles de contraseñas. El presupuesto recorta la salida a los N
+// candidatos con mejor puntaje (ver rank.go), ya sea para toda la
+// corrida o para un módulo en particular.
+//
+// La selección usa un min-heap de tamaño N: el peor candidato retenido
+// está siempre en la raíz y se reemplaza cuando llega uno mejor, así que
+// el heap queda acotado por N y no por el total generado. Aparte se
+// guarda el orden en que apareció cada candidato distinto: uno que fue
+// desplazado y vuelve con mejor puntaje conserva su lugar original, y
+// así -limit N -rank es un prefijo de la salida de -rank.
+// ================================================================
+
+// ParseModuleLimits interpreta una lista "módulo=N" separada por comas.
+// Ej: "dni=50000,locale=1000" → {"dni": 50000, "locale": 1000}
+func ParseModuleLimits(raw string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("límite por módulo inválido %q (usar módulo=N)", part)
+		}
+		if err := SetModuleLimit(limits, name, value); err != nil {
+			return nil, err
+		}
+	}
+	return limits, nil
+}
+
+// SetModuleLimit valida el módulo (ver generator.go) y el número y guarda
+// el límite en limits.
+func SetModuleLimit(limits map[string]int, name, value string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if LookupGenerator(name) == nil {
+		return unknownModule(name)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return fmt.Errorf("límite inválido %q para el módulo %s", value, name)
+	}
+	limits[name] = n
+	return nil
+}
+
+// budgeted envía la salida de gen a out, recortada a los limit candidatos
+// de mejor puntaje si limit > 0. Los retenidos salen en orden de generación.
+// accept filtra antes de competir por el cupo, para no gastarlo en descartes.
+func budgeted(limit int, accept func(string) (string, bool), gen func(emit Sink), out Sink) {
+	if limit <= 0 {
+		gen(out)
+		return
+	}
+	top := newTopN(limit)
+	gen(func(s string, score float64) {
+		if s, ok := accept(s); ok {
+			top.add(s, score)
+		}
+	})
+	top.flush(false, out)
+}
+
+// ── top-N ─────────────────────────────────────────────────────────
+
+type topItem struct {
+	Candidate
+	seq int // orden de primera aparición, para desempatar y para el flush sin ranking
+}
+
+// topN retiene los n candidatos distintos de mayor puntaje.
+type topN struct {
+	n     int
+	index map[string]int // valor → posición en items
+	first map[string]int // valor → orden de primera aparición
+	items []topItem
+}
+
+func newTopN(n int) *topN {
+	return &topN{n: n, index: make(map[string]int, n), first: make(map[string]int, n)}
+}
+
+func (t *topN) add(s string, score float64) {
+	// Ya retenido: se queda con el mejor puntaje
+	if i, ok := t.index[s]; ok {
+		if score > t.items[i].Score {
+			t.items[i].Score = score
+			heap.Fix(t, i)
+		}
+		return
+	}
+
+	seq, ok := t.first[s]
+	if !ok {
+		seq = len(t.first)
+		t.first[s] = seq
+	}
+	it := topItem{Candidate{s, score}, seq}
+	if len(t.items) < t.n {
+		heap.Push(t, it)
+		return
+	}
+	// Lleno: entra solo si supera al peor retenido
+	if t.less(t.items[0], it) {
+		delete(t.index, t.items[0].Value)
+		t.items[0] = it
+		t.index[s] = 0
+		heap.Fix(t, 0)
+	}
+}
+
+// flush emite los retenidos ordenados por puntaje (ranked) o por orden de llegada.
+func (t *topN) flush(ranked bool, emit Sink) {
+	items := t.items
+	sort.Slice(items, func(i, j int) bool {
+		if ranked && items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].seq < items[j].seq
+	})
+	for _, it := range items {
+		emit(it.Value, it.Score)
+	}
+	t.index, t.first, t.items = nil, nil, nil
+}
+
+// less ordena de peor a mejor: menor puntaje primero y, a igual puntaje,
+// el que llegó después (así se conservan los primeros generados).
+func (t *topN) less(a, b topItem) bool {
+	if a.Score != b.Score {
+		return a.Score < b.Score
+	}
+	return a.seq > b.seq
+}
+
+// heap.Interface — min-heap con el peor candidato en la raíz.
+func (t *topN) Len() int           { return len(t.items) }
+func (t *topN) Less(i, j int) bool { return t.less(t.items[i], t.items[j]) }
+func (t *topN) Swap(i, j int) {
+	t.items[i], t.items[j] = t.items[j], t.items[i]
+	t.index[t.items[i].Value] = i
+	t.index[t.items[j].Value] = j
+}
+func (t *topN) Push(x any) {
+	it := x.(topItem)
+	t.index[it.Value] = len(t.items)
+	t.items = append(t.items, it)
+}
+func (t *topN) Pop() any {
+	old := t.items
+	it := old[len(old)-1]
+	t.items = old[:len(old)-1]
+	delete(t.index, it.Value)
+	return it
+}
@@ -0,0 +1,36 @@
+package core
+
+import "testing"
+
+// Con -limit N -rank la salida tiene que ser un prefijo de la de -rank,
+// aun cuando un candidato desplazado vuelve con mejor puntaje.
+func TestTopNPrefixOfRanking(t *testing.T) {
+	stream := []Candidate{
+		{"a", 1}, {"b", 1}, {"c", 1}, {"d", 2}, {"e", 2},
+		{"a", 2}, // desplazado por d y e, vuelve empatado con ellos
+		{"f", 3}, {"b", 0.5}, {"c", 3},
+	}
+	r := newRanker()
+	for _, c := range stream {
+		r.add(c.Value, c.Score)
+	}
+	var full []string
+	r.flush(func(s string, _ float64) { full = append(full, s) })
+
+	for n := 1; n <= len(full); n++ {
+		top := newTopN(n)
+		for _, c := range stream {
+			top.add(c.Value, c.Score)
+		}
+		var got []string
+		top.flush(true, func(s string, _ float64) { got = append(got, s) })
+		if len(got) != n {
+			t.Fatalf("limit %d: %d candidatos", n, len(got))
+		}
+		for i := range got {
+			if got[i] != full[i] {
+				t.Fatalf("limit %d: %v no es prefijo de %v", n, got, full)
+			}
+		}
+	}
+}
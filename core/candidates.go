@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"trickster/utils"
+)
+
+// ================================================================
+// SET COMPARTIDO DE CANDIDATOS
+//
+// Todos los módulos del perfil escriben en un único CandidateSet.
+// Acá se aplica la política de contraseñas (policy.go; por defecto la
+// regla histórica de 4–28 runas) y la deduplicación, con costo O(1)
+// por inserción: reemplaza al antiguo appendUniq, que recorría todo el
+// resultado en cada merge.
+//
+// La deduplicación en sí la hace un utils.Deduper, así que el mismo
+// set puede usar un map exacto, un filtro de Bloom o sort-merge en disco;
+// con ranking activo la hace el ranker (rank.go), que conserva el mejor
+// puntaje de cada candidato.
+// ================================================================
+
+// CandidateOptions configura el set compartido de candidatos.
+type CandidateOptions struct {
+	Dedup utils.DedupConfig
+	Rank  bool // ordenar la salida por puntaje (ignora Dedup: deduplica en memoria)
+	Limit int  // quedarse con los Limit mejores candidatos (0 = sin límite, ignora Dedup)
+	// Policy filtra los candidatos (nil = DefaultPolicy). Se compila al crear el set.
+	Policy *Policy
+}
+
+// CandidateSet filtra, deduplica y reenvía candidatos al siguiente Sink.
+type CandidateSet struct {
+	policy *Policy
+	dedup  utils.Deduper
+	rank   *ranker
+	top    *topN
+	ranked bool
+	next   Sink
+	score  float64 // puntaje del candidato que se está procesando
+	count  int
+	err    error
+}
+
+// NewCandidateSet crea un set exacto en memoria que reenvía cada candidato nuevo a next.
+func NewCandidateSet(next Sink) *CandidateSet {
+	cs, _ := NewCandidateSetWith(CandidateOptions{}, next)
+	return cs
+}
+
+// NewCandidateSetWith crea un set con el backend de deduplicación indicado
+// o, si opts.Rank está activo, con el ranking por probabilidad. Con
+// opts.Limit > 0 solo se retienen los mejores candidatos (ver budget.go).
+func NewCandidateSetWith(opts CandidateOptions, next Sink) (*CandidateSet, error) {
+	cs := &CandidateSet{policy: opts.Policy, next: next}
+	if cs.policy == nil {
+		cs.policy = DefaultPolicy()
+	}
+	if err := cs.policy.Compile(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case opts.Limit > 0:
+		cs.top, cs.ranked = newTopN(opts.Limit), opts.Rank
+	case opts.Rank:
+		cs.rank = newRanker()
+	default:
+		d, err := utils.NewDeduper(opts.Dedup)
+		if err != nil {
+			return nil, err
+		}
+		cs.dedup = d
+	}
+	return cs, nil
+}
+
+// Add normaliza s y lo reenvía si cumple la política y no se vio antes.
+// Tiene la firma de Sink para pasarse directo a los generadores.
+func (cs *CandidateSet) Add(s string, score float64) {
+	if cs.err != nil {
+		return
+	}
+	s, ok := cs.accept(s)
+	if !ok {
+		return
+	}
+	if cs.top != nil {
+		cs.top.add(s, score)
+		return
+	}
+	if cs.rank != nil {
+		cs.rank.add(s, score)
+		return
+	}
+	// Los backends en streaming emiten dentro de Add, así que forward
+	// puede tomar el puntaje de cs.score. El backend en disco emite
+	// recién en Close y sus candidatos salen sin puntaje.
+	cs.score = score
+	cs.err = cs.dedup.Add(s, cs.forward)
+}
+
+// Close vuelca lo que quede pendiente (ranking o sort-merge en disco)
+// y devuelve el primer error ocurrido durante la generación.
+func (cs *CandidateSet) Close() error {
+	if cs.err != nil {
+		return cs.err
+	}
+	if cs.top != nil {
+		cs.top.flush(cs.ranked, cs.emitScored)
+		return nil
+	}
+	if cs.rank != nil {
+		cs.rank.flush(cs.emitScored)
+		return nil
+	}
+	cs.score = 0
+	cs.err = cs.dedup.Flush(cs.forward)
+	return cs.err
+}
+
+// Len devuelve cuántos candidatos distintos se reenviaron.
+func (cs *CandidateSet) Len() int {
+	return cs.count
+}
+
+func (cs *CandidateSet) forward(s string) {
+	cs.emitScored(s, cs.score)
+}
+
+func (cs *CandidateSet) emitScored(s string, score float64) {
+	cs.count++
+	cs.next(s, score)
+}
+
+// accept recorta espacios y aplica la política del set.
+func (cs *CandidateSet) accept(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if !cs.policy.Allows(s) {
+		return "", false
+	}
+	return s, true
+}
+
+// askDedupConfig pregunta qué backend de deduplicación usar.
+// Enter deja el map exacto en memoria, que alcanza para casi todos los casos.
+func askDedupConfig() utils.DedupConfig {
+	for {
+		answer := strings.ToLower(utils.AskOptional("Deduplicación [exact/bloom/disk] (Enter = exact)"))
+		switch answer {
+		case "", utils.DedupExact:
+			return utils.DedupConfig{}
+		case utils.DedupDisk:
+			return utils.DedupConfig{Backend: utils.DedupDisk}
+		case utils.DedupBloom:
+			cfg := utils.DedupConfig{Backend: utils.DedupBloom}
+			if raw := utils.AskOptional("Tasa de falsos positivos del Bloom (ej: 0.001)"); raw != "" {
+				fp, err := strconv.ParseFloat(raw, 64)
+				if err != nil || fp <= 0 || fp >= 1 {
+					utils.Warn("Tasa inválida, se usa 0.001.")
+				} else {
+					cfg.FPRate = fp
+				}
+			}
+			return cfg
+		default:
+			utils.Error(fmt.Sprintf("Backend desconocido %q.", answer))
+		}
+	}
+}
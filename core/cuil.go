@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ================================================================
+// CUIL / CUIT
+//
+// Formato: PP-NNNNNNNN-D
+//   PP  prefijo: 20 varón, 27 mujer, 23/24 casos especiales,
+//       30/33/34 personas jurídicas (CUIT de empresas)
+//   N   DNI (o número de sociedad) de 8 dígitos, con ceros a la izquierda
+//   D   dígito verificador módulo 11 con pesos 5,4,3,2,7,6,5,4,3,2
+//
+// Si el verificador da 10, AFIP reemplaza 20 por 23 (verificador 9) y
+// 27 por 23 (verificador 4); con los demás prefijos ese número no se
+// asigna. Así, con un DNI conocido hay a lo sumo un CUIL válido por
+// prefijo, no diez.
+// ================================================================
+
+// Prefijos de CUIL (personas físicas) y de CUIT de empresas. Solo se
+// generan CUIL: el perfil es de una persona y no tiene número de
+// sociedad. CUITCompanyPrefixes sirve únicamente para que ValidCUIL (y
+// la validación del documento del pack argentino) acepte un CUIT de
+// empresa cargado a mano.
+var (
+	CUILPrefixes        = []string{"20", "27", "23", "24"}
+	CUITCompanyPrefixes = []string{"30", "33", "34"}
+)
+
+var cuilWeights = [10]int{5, 4, 3, 2, 7, 6, 5, 4, 3, 2}
+
+// CUIL calcula el CUIL/CUIT válido para prefix y number. Devuelve el
+// prefijo efectivo y el verificador. Si el módulo 11 da 10 se aplica la
+// regla de AFIP: 20 pasa a 23 con verificador 9 y 27 a 23 con 4; con
+// cualquier otro prefijo ese número no tiene CUIL y se devuelve error.
+func CUIL(prefix string, number int) (string, int, error) {
+	if len(prefix) != 2 || number < 0 || number > 99_999_999 {
+		return "", 0, fmt.Errorf("CUIL inválido: prefijo %q, número %d", prefix, number)
+	}
+	if _, err := strconv.Atoi(prefix); err != nil {
+		return "", 0, fmt.Errorf("CUIL inválido: prefijo %q", prefix)
+	}
+
+	dv := cuilCheckDigit(prefix, number)
+	if dv != 10 {
+		return prefix, dv, nil
+	}
+	switch prefix {
+	case "20":
+		return "23", 9, nil
+	case "27":
+		return "23", 4, nil
+	}
+	return "", 0, fmt.Errorf("CUIL inválido: con prefijo %s el número %08d da verificador 10", prefix, number)
+}
+
+// FormatCUIL devuelve el CUIL compacto (20301234569) y con guiones
+// (20-30123456-9). Un verificador fuera de 0-9 no es un CUIL: devuelve
+// cadenas vacías.
+func FormatCUIL(prefix string, number, dv int) (compact, dashed string) {
+	if dv < 0 || dv > 9 {
+		return "", ""
+	}
+	body := fmt.Sprintf("%08d", number)
+	d := strconv.Itoa(dv)
+	return prefix + body + d, prefix + "-" + body + "-" + d
+}
+
+// ValidCUIL indica si s (con o sin guiones) es un CUIL/CUIT con prefijo
+// conocido y verificador correcto.
+func ValidCUIL(s string) bool {
+	s = cleanDocument(s)
+	if len(s) != 11 || !knownCUILPrefix(s[:2]) {
+		return false
+	}
+	number, err := strconv.Atoi(s[2:10])
+	if err != nil {
+		return false
+	}
+	dv := int(s[10] - '0')
+	return dv >= 0 && dv <= 9 && cuilCheckDigit(s[:2], number) == dv
+}
+
+func knownCUILPrefix(prefix string) bool {
+	for _, list := range [][]string{CUILPrefixes, CUITCompanyPrefixes} {
+		for _, p := range list {
+			if p == prefix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cuilCheckDigit aplica módulo 11; devuelve 10 cuando hace falta cambiar el prefijo.
+func cuilCheckDigit(prefix string, number int) int {
+	digits := prefix + fmt.Sprintf("%08d", number)
+	sum := 0
+	for i, w := range cuilWeights {
+		sum += int(digits[i]-'0') * w
+	}
+	r := 11 - sum%11
+	if r == 11 {
+		return 0
+	}
+	return r
+}
+
+// argRelatedIDs devuelve los CUIL válidos (compactos) de un DNI, uno por
+// prefijo de persona física y sin repetidos: el prefijo 23 aparece
+// también como corrección de 20/27.
+func argRelatedIDs(dni int) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, prefix := range CUILPrefixes {
+		pre, dv, err := CUIL(prefix, dni)
+		if err != nil {
+			continue // ese prefijo no tiene CUIL para este DNI
+		}
+		compact, _ := FormatCUIL(pre, dni, dv)
+		if !seen[compact] {
+			seen[compact] = true
+			ids = append(ids, compact)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,71 @@
+package core
+
+import "testing"
+
+func TestCUIL(t *testing.T) {
+	tests := []struct {
+		prefix string
+		number int
+		want   string // compacto; "" = error
+	}{
+		{"20", 12345678, "20123456786"},
+		{"33", 69345023, "33693450239"}, // AFIP
+		{"30", 54668997, "30546689979"}, // YPF
+		{"20", 30123458, "23301234589"}, // verificador 10: 20 → 23 con 9
+		{"27", 30123455, "23301234554"}, // verificador 10: 27 → 23 con 4
+		{"23", 30123452, ""},            // verificador 10 sin corrección
+		{"23", 10000013, ""},
+		{"2", 12345678, ""},
+		{"20", 123456789, ""},
+	}
+	for _, tt := range tests {
+		pre, dv, err := CUIL(tt.prefix, tt.number)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("CUIL(%s, %d) = %s, %d; se esperaba error", tt.prefix, tt.number, pre, dv)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("CUIL(%s, %d): %v", tt.prefix, tt.number, err)
+			continue
+		}
+		compact, _ := FormatCUIL(pre, tt.number, dv)
+		if compact != tt.want {
+			t.Errorf("CUIL(%s, %d) = %s, se esperaba %s", tt.prefix, tt.number, compact, tt.want)
+		}
+		if !ValidCUIL(compact) {
+			t.Errorf("ValidCUIL(%s) = false", compact)
+		}
+	}
+}
+
+func TestValidCUIL(t *testing.T) {
+	tests := []struct {
+		cuil string
+		want bool
+	}{
+		{"20-12345678-6", true},
+		{"33-69345023-9", true},
+		{"23-30123458-9", true},
+		{"20-12345678-5", false},
+		{"99-12345678-6", false},
+		{"2012345678", false},
+	}
+	for _, tt := range tests {
+		if got := ValidCUIL(tt.cuil); got != tt.want {
+			t.Errorf("ValidCUIL(%q) = %v, se esperaba %v", tt.cuil, got, tt.want)
+		}
+	}
+}
+
+// Ningún CUIL generado desde un DNI puede tener verificador de dos dígitos.
+func TestArgRelatedIDsValid(t *testing.T) {
+	for dni := 10000000; dni < 10000200; dni++ {
+		for _, id := range argRelatedIDs(dni) {
+			if len(id) != 11 || !ValidCUIL(id) {
+				t.Fatalf("argRelatedIDs(%d) incluye %q, que no es un CUIL válido", dni, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"trickster/utils"
+	"unicode"
+)
+
+// ================================================================
+// LECTURA DE FECHAS
+//
+// ParseDate acepta las fechas como las escribe quien carga el perfil:
+//
+//   15031990  15/03/1990  15-3-90  1990-03-15  19900315
+//   15 de marzo de 1990  march 15, 1990  marzo 1990  03/1990  1990
+//
+// Las fechas pueden ser parciales (solo año, o mes y año). Ante
+// 03/04/1990 se sigue la convención local DD/MM, pero la fecha queda
+// marcada como ambigua para avisarle al usuario; si DD/MM es imposible
+// y MM/DD no (03/15/1990), se toma MM/DD y también se avisa.
+// ================================================================
+
+// PartialDate es una fecha posiblemente incompleta: Day y Month valen 0
+// cuando no se conocen.
+type PartialDate struct {
+	Day, Month, Year int
+	Ambiguous        bool // el día y el mes se pueden leer al revés
+}
+
+// monthNumbers: nombre o abreviatura de mes (ES/EN) → número.
+var monthNumbers = buildMonthNumbers()
+
+func buildMonthNumbers() map[string]int {
+	m := map[string]int{"setiembre": 9, "set": 9, "sept": 9}
+	for _, names := range [][]string{monthsES, monthsESShort, monthsEN, monthsENShort} {
+		for i, name := range names {
+			m[name] = i + 1
+		}
+	}
+	return m
+}
+
+var dateAccents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u")
+
+// ParseDate interpreta una fecha completa o parcial (ver arriba). El error
+// explica qué no se entendió.
+func ParseDate(raw string) (PartialDate, error) {
+	s := dateAccents.Replace(strings.ToLower(strings.TrimSpace(raw)))
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(tokens) == 0 {
+		return PartialDate{}, fmt.Errorf("fecha vacía")
+	}
+
+	var d PartialDate
+	var err error
+	if strings.IndexFunc(s, unicode.IsLetter) >= 0 {
+		d, err = parseTextDate(tokens)
+	} else {
+		d, err = parseNumericDate(tokens)
+	}
+	if err != nil {
+		return PartialDate{}, fmt.Errorf("fecha %q: %w", raw, err)
+	}
+	if err := d.check(); err != nil {
+		return PartialDate{}, fmt.Errorf("fecha %q: %w", raw, err)
+	}
+	return d, nil
+}
+
+// parseTextDate: fechas con el nombre del mes.
+func parseTextDate(tokens []string) (PartialDate, error) {
+	var d PartialDate
+	var nums []string
+	for _, t := range tokens {
+		switch t {
+		case "de", "del", "of", "the":
+			continue
+		}
+		if m, ok := monthNumbers[t]; ok {
+			if d.Month != 0 {
+				return d, fmt.Errorf("tiene dos meses")
+			}
+			d.Month = m
+			continue
+		}
+		// 15th, 1st, 1ro
+		n := strings.TrimRightFunc(t, unicode.IsLetter)
+		if _, err := strconv.Atoi(n); err != nil {
+			return d, fmt.Errorf("no se reconoce %q", t)
+		}
+		nums = append(nums, n)
+	}
+	if d.Month == 0 {
+		return d, fmt.Errorf("no se reconoce el mes")
+	}
+
+	for _, n := range nums {
+		v, _ := strconv.Atoi(n)
+		switch {
+		case len(n) == 4 && d.Year == 0:
+			d.Year = v
+		case len(n) <= 2 && d.Day == 0 && v >= 1 && v <= 31:
+			d.Day = v
+		case len(n) == 2 && d.Year == 0:
+			d.Year = expandYear(v)
+		default:
+			return d, fmt.Errorf("no se entiende el número %q", n)
+		}
+	}
+	if d.Year == 0 {
+		return d, fmt.Errorf("falta el año")
+	}
+	return d, nil
+}
+
+// parseNumericDate: fechas solo con números, con o sin separadores.
+func parseNumericDate(tokens []string) (PartialDate, error) {
+	num := func(s string) int { v, _ := strconv.Atoi(s); return v }
+
+	switch len(tokens) {
+	case 1:
+		return parseCompactDate(tokens[0])
+
+	case 2:
+		a, b := tokens[0], tokens[1]
+		switch {
+		case len(a) == 4:
+			return PartialDate{Month: num(b), Year: num(a)}, nil // 1990-03
+		case len(b) == 4 || (len(b) == 2 && num(a) <= 12):
+			return PartialDate{Month: num(a), Year: yearOf(b)}, nil // 03/1990, 03/90
+		}
+		return PartialDate{}, fmt.Errorf("falta el año")
+
+	case 3:
+		a, b, c := tokens[0], tokens[1], tokens[2]
+		if len(a) == 4 {
+			return PartialDate{Day: num(c), Month: num(b), Year: num(a)}, nil // 1990-03-15
+		}
+		if len(c) != 2 && len(c) != 4 {
+			return PartialDate{}, fmt.Errorf("el año debe tener 2 o 4 dígitos")
+		}
+		d := PartialDate{Day: num(a), Month: num(b), Year: yearOf(c)}
+		switch {
+		case d.Month > 12 && d.Day <= 12:
+			// Solo tiene sentido como MM/DD
+			d.Day, d.Month, d.Ambiguous = d.Month, d.Day, true
+		case d.Day <= 12 && d.Month <= 12 && d.Day != d.Month:
+			d.Ambiguous = true
+		}
+		return d, nil
+	}
+	return PartialDate{}, fmt.Errorf("formato no reconocido")
+}
+
+// parseCompactDate: DDMMAAAA, AAAAMMDD, DDMMAA, MMAAAA o AAAA.
+func parseCompactDate(s string) (PartialDate, error) {
+	num := func(s string) int { v, _ := strconv.Atoi(s); return v }
+
+	switch len(s) {
+	case 8:
+		d := PartialDate{Day: num(s[0:2]), Month: num(s[2:4]), Year: num(s[4:8])}
+		if d.check() != nil {
+			// 19900315
+			if alt := (PartialDate{Day: num(s[6:8]), Month: num(s[4:6]), Year: num(s[0:4])}); alt.check() == nil {
+				return alt, nil
+			}
+		}
+		return d, nil
+	case 6:
+		// MMAAAA si los últimos 4 son un año posible, si no DDMMAA
+		if m := (PartialDate{Month: num(s[0:2]), Year: num(s[2:6])}); m.check() == nil {
+			return m, nil
+		}
+		return PartialDate{Day: num(s[0:2]), Month: num(s[2:4]), Year: expandYear(num(s[4:6]))}, nil
+	case 4:
+		if d := (PartialDate{Year: num(s)}); d.check() == nil {
+			return d, nil
+		}
+		return PartialDate{}, fmt.Errorf("falta el año (¿DDMM?)")
+	}
+	return PartialDate{}, fmt.Errorf("se esperaban 8 dígitos (DDMMAAAA), 6 (MMAAAA) o 4 (AAAA)")
+}
+
+// yearOf convierte un año de 2 o 4 dígitos.
+func yearOf(s string) int {
+	v, _ := strconv.Atoi(s)
+	if len(s) <= 2 {
+		return expandYear(v)
+	}
+	return v
+}
+
+// expandYear completa un año de 2 dígitos: hasta el año de referencia
+// (ver utils.ReferenceDate) es 20xx, el resto 19xx.
+func expandYear(yy int) int {
+	if yy <= utils.ReferenceYear()%100 {
+		return 2000 + yy
+	}
+	return 1900 + yy
+}
+
+// check valida los rangos de año, mes y día (incluidos los bisiestos).
+func (d PartialDate) check() error {
+	if d.Year < 1900 || d.Year > 2100 {
+		return fmt.Errorf("año fuera de rango: %d", d.Year)
+	}
+	if d.Month == 0 {
+		if d.Day != 0 {
+			return fmt.Errorf("día sin mes")
+		}
+		return nil
+	}
+	if d.Month < 1 || d.Month > 12 {
+		return fmt.Errorf("mes inválido: %d", d.Month)
+	}
+	if d.Day != 0 {
+		t := time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC)
+		if d.Day < 1 || t.Day() != d.Day {
+			return fmt.Errorf("el %d de %s de %d no existe", d.Day, monthsES[d.Month-1], d.Year)
+		}
+	}
+	return nil
+}
+
+// Compact devuelve la fecha como DDMMAAAA, MMAAAA o AAAA según lo que se conozca.
+func (d PartialDate) Compact() string {
+	switch {
+	case d.Day != 0:
+		return fmt.Sprintf("%02d%02d%04d", d.Day, d.Month, d.Year)
+	case d.Month != 0:
+		return fmt.Sprintf("%02d%04d", d.Month, d.Year)
+	}
+	return fmt.Sprintf("%04d", d.Year)
+}
+
+// Parts devuelve día, mes y año con ceros a la izquierda; vacíos si no se conocen.
+func (d PartialDate) Parts() (dia, mes, anio string) {
+	if d.Day != 0 {
+		dia = fmt.Sprintf("%02d", d.Day)
+	}
+	if d.Month != 0 {
+		mes = fmt.Sprintf("%02d", d.Month)
+	}
+	return dia, mes, fmt.Sprintf("%04d", d.Year)
+}
+
+// String escribe la fecha en castellano: 15 de marzo de 1990.
+func (d PartialDate) String() string {
+	switch {
+	case d.Day != 0:
+		return fmt.Sprintf("%d de %s de %d", d.Day, monthsES[d.Month-1], d.Year)
+	case d.Month != 0:
+		return fmt.Sprintf("%s de %d", monthsES[d.Month-1], d.Year)
+	}
+	return strconv.Itoa(d.Year)
+}
+
+// SetReferenceDate fija la fecha de referencia del engagement (ver
+// utils.ReferenceDate) a partir de una fecha en cualquier formato de
+// ParseDate. Si falta el día o el mes se toma el último: "2019" cubre
+// todo 2019.
+func SetReferenceDate(raw string) (PartialDate, error) {
+	d, err := ParseDate(raw)
+	if err != nil {
+		return d, err
+	}
+	month, day := d.Month, d.Day
+	if month == 0 {
+		month = 12
+	}
+	t := time.Date(d.Year, time.Month(month)+1, 0, 0, 0, 0, 0, time.Local)
+	if day != 0 {
+		t = time.Date(d.Year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+	}
+	utils.SetReferenceDate(t)
+	return d, nil
+}
+
+// WarnAmbiguousDate avisa cómo se interpretó una fecha que se puede leer
+// de dos formas. La usan tanto el menú como la CLI.
+func WarnAmbiguousDate(raw string, d PartialDate) {
+	if d.Ambiguous {
+		utils.Warn(fmt.Sprintf("La fecha %q es ambigua; se tomó como %s.", raw, d))
+	}
+}
+
+// askDate pregunta una fecha hasta que sea válida o quede en blanco, y
+// la devuelve normalizada (ver PartialDate.Compact).
+func askDate(question string) string {
+	for {
+		raw := utils.AskOptional(question)
+		if raw == "" {
+			return ""
+		}
+		d, err := ParseDate(raw)
+		if err != nil {
+			utils.Error(err.Error())
+			continue
+		}
+		WarnAmbiguousDate(raw, d)
+		return d.Compact()
+	}
+}
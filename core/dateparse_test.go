@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+	"time"
+	"trickster/utils"
+)
+
+func TestParseDate(t *testing.T) {
+	// Los años de 2 dígitos dependen de la fecha de referencia
+	utils.SetReferenceDate(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	defer utils.SetReferenceDate(time.Time{})
+
+	tests := []struct {
+		raw  string
+		want PartialDate
+	}{
+		{"15031990", PartialDate{15, 3, 1990, false}},
+		{"15/03/1990", PartialDate{15, 3, 1990, false}},
+		{"15-3-90", PartialDate{15, 3, 1990, false}},
+		{"1990-03-15", PartialDate{15, 3, 1990, false}},
+		{"19900315", PartialDate{15, 3, 1990, false}},
+		{"01-01-05", PartialDate{1, 1, 2005, false}},
+		{"29/02/1992", PartialDate{29, 2, 1992, false}},
+		{"15 de marzo de 1990", PartialDate{15, 3, 1990, false}},
+		{"march 15th, 1990", PartialDate{15, 3, 1990, false}},
+		{"Setiembre 1990", PartialDate{0, 9, 1990, false}},
+		{"03/1990", PartialDate{0, 3, 1990, false}},
+		{"1990", PartialDate{0, 0, 1990, false}},
+
+		// Ambiguas: se toma DD/MM salvo que sea imposible
+		{"03/04/1990", PartialDate{3, 4, 1990, true}},
+		{"03/15/1990", PartialDate{15, 3, 1990, true}},
+		{"04/04/1990", PartialDate{4, 4, 1990, false}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.raw)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDate(%q) = %+v, se esperaba %+v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"31/02/1990",
+		"29/02/1991",
+		"13/13/1990",
+		"15/03/199",
+		"1503",
+		"1850",
+		"marzo",
+		"abril mayo 1990",
+		"15 de marzi de 1990",
+	} {
+		if d, err := ParseDate(raw); err == nil {
+			t.Errorf("ParseDate(%q) = %+v; se esperaba error", raw, d)
+		}
+	}
+}
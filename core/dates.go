@@ -0,0 +1,159 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"trickster/utils"
+)
+
+// ================================================================
+// FECHAS SIGNIFICATIVAS
+//
+// Además del nacimiento, el objetivo suele usar otras fechas: el
+// aniversario, el casamiento, el cumpleaños de los hijos, la graduación.
+// Cada una se guarda con su etiqueta en Profile.Fechas y se expande a
+// todos los formatos numéricos y a los nombres de mes en español e
+// inglés, completos y abreviados: 15marzo, marzo90, 15mar1990, march15.
+// ================================================================
+
+// SignificantDate es una fecha del objetivo con su etiqueta.
+type SignificantDate struct {
+	Etiqueta string `json:"etiqueta,omitempty" yaml:"etiqueta,omitempty"` // aniversario, casamiento, hijo...
+	Fecha    string `json:"fecha" yaml:"fecha"`                           // DDMMAAAA, MMAAAA o AAAA (ver ParseDate)
+}
+
+// Parts separa la fecha en día, mes y año (día y mes vacíos si no se
+// conocen); ok es false si la fecha no se entiende.
+func (d SignificantDate) Parts() (dia, mes, anio string, ok bool) {
+	pd, err := ParseDate(d.Fecha)
+	if err != nil {
+		return "", "", "", false
+	}
+	dia, mes, anio = pd.Parts()
+	return dia, mes, anio, true
+}
+
+// Nombres de mes ordenados de enero a diciembre.
+var (
+	monthsES      = []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+	monthsESShort = []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"}
+	monthsEN      = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"}
+	monthsENShort = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+)
+
+// DateFormats genera las formas en que se escribe una fecha, de la más a
+// la menos frecuente: primero las numéricas y después con el nombre del
+// mes en español y en inglés. Sin día se generan solo las de mes y año;
+// sin mes no hay nada que agregar al año suelto.
+func DateFormats(dia, mes, anio string) []string {
+	if mes == "" || len(anio) < 2 {
+		return nil
+	}
+	corto := anio[len(anio)-2:]
+	if dia == "" {
+		return monthYearFormats(mes, anio, corto)
+	}
+	formats := []string{
+		dia + mes + anio,
+		anio + mes + dia,
+		dia + mes + corto,
+		dia + mes,
+		mes + anio,
+		mes + dia,
+		anio + dia + mes,
+		dia + "-" + mes + "-" + anio,
+		dia + "/" + mes + "/" + anio,
+		dia + "." + mes + "." + anio,
+		anio + "-" + mes + "-" + dia,
+	}
+
+	m, err := strconv.Atoi(mes)
+	if err != nil || m < 1 || m > 12 {
+		return formats
+	}
+	d := strings.TrimPrefix(dia, "0")
+	seen := make(map[string]bool, 64)
+	for _, name := range []string{monthsES[m-1], monthsESShort[m-1], monthsEN[m-1], monthsENShort[m-1]} {
+		for _, f := range []string{
+			dia + name,
+			name + anio,
+			name + corto,
+			dia + name + anio,
+			dia + name + corto,
+			name + dia,
+			capFirst(name) + anio,
+			d + name,
+			name + d,
+		} {
+			if !seen[f] {
+				seen[f] = true
+				formats = append(formats, f)
+			}
+		}
+	}
+	return formats
+}
+
+// monthYearFormats son las formas de una fecha con mes y año pero sin día.
+func monthYearFormats(mes, anio, corto string) []string {
+	formats := []string{
+		mes + anio,
+		mes + corto,
+		anio + mes,
+		mes + "/" + anio,
+		mes + "-" + anio,
+	}
+	m, err := strconv.Atoi(mes)
+	if err != nil || m < 1 || m > 12 {
+		return formats
+	}
+	for _, name := range []string{monthsES[m-1], monthsESShort[m-1], monthsEN[m-1]} {
+		formats = append(formats, name+anio, name+corto, capFirst(name)+anio)
+	}
+	return formats
+}
+
+// profileDate es una fecha del perfil ya expandida, con el peso de su origen.
+type profileDate struct {
+	formats []string
+	w       float64
+}
+
+// profileDates devuelve la fecha de nacimiento y las fechas significativas
+// válidas del perfil, en ese orden.
+func profileDates(p Profile) []profileDate {
+	var dates []profileDate
+	if formats := DateFormats(p.Dia, p.Mes, p.Anio); formats != nil {
+		dates = append(dates, profileDate{formats, 0.7})
+	}
+	for k, d := range p.Fechas {
+		if dia, mes, anio, ok := d.Parts(); ok {
+			if formats := DateFormats(dia, mes, anio); formats != nil {
+				dates = append(dates, profileDate{formats, 0.6 * freq(k)})
+			}
+		}
+	}
+	return dates
+}
+
+// askDates pregunta las fechas significativas del objetivo, hasta 10.
+func askDates() []SignificantDate {
+	fmt.Println()
+	utils.Info("Fechas significativas (aniversario, casamiento, cumpleaños de hijos, graduación...).")
+	utils.Info("Dejá en blanco la fecha para terminar.")
+
+	var dates []SignificantDate
+	for i := 1; i <= 10; i++ {
+		fecha := askDate(fmt.Sprintf("  Fecha %d (ej: 15/03/2015, marzo 2015)", i))
+		if fecha == "" {
+			break
+		}
+		etiqueta := utils.AskOptional("  Etiqueta (ej: aniversario, casamiento, hijo)")
+		dates = append(dates, SignificantDate{
+			Etiqueta: strings.TrimSpace(etiqueta),
+			Fecha:    fecha,
+		})
+	}
+	return dates
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"trickster/utils"
+)
+
+// ================================================================
+// ATRIBUTOS DERIVADOS DE LA FECHA DE NACIMIENTO
+//
+// De la fecha de nacimiento salen datos que el objetivo no carga pero
+// sí usa en sus contraseñas:
+//
+//   edad       34 (a la fecha de referencia), 33, la edad al casarse...
+//   signo      piscis / pisces
+//   chino      caballo
+//   día        jueves
+//   década     90s, los90
+//
+// Se agregan como átomos en buildAtoms, con pesos bajos: son pistas
+// más débiles que el nombre o el año. Por eso tampoco pasan por toda
+// la expansión: solo salen solos, Capitalizados y con el año (piscis,
+// Piscis1990, Piscis90), sin sufijos ni combinaciones con otros campos.
+// Solo el signo va también en inglés; el animal chino y el día casi no
+// aparecen traducidos.
+// ================================================================
+
+// DerivedAttributes son los datos deducidos de la fecha de nacimiento.
+// Cada lista va de la forma más a la menos probable; vacía si no se
+// puede deducir (ej: el signo sin día y mes).
+type DerivedAttributes struct {
+	Edades     []int    // a la fecha de referencia, un año antes y en cada fecha significativa
+	Signo      []string // zodíaco: español, inglés
+	SignoChino []string // horóscopo chino; dos si nació cerca del año nuevo chino
+	DiaSemana  string   // día de la semana del nacimiento
+	Decada     []string // 90s, los90
+}
+
+// zodiacSigns: día en que empieza cada signo, en orden del año.
+var zodiacSigns = []struct {
+	mes, dia int
+	es, en   string
+}{
+	{1, 20, "acuario", "aquarius"},
+	{2, 19, "piscis", "pisces"},
+	{3, 21, "aries", "aries"},
+	{4, 20, "tauro", "taurus"},
+	{5, 21, "geminis", "gemini"},
+	{6, 21, "cancer", "cancer"},
+	{7, 23, "leo", "leo"},
+	{8, 23, "virgo", "virgo"},
+	{9, 23, "libra", "libra"},
+	{10, 23, "escorpio", "scorpio"},
+	{11, 22, "sagitario", "sagittarius"},
+	{12, 22, "capricornio", "capricorn"},
+}
+
+// chineseAnimals: los 12 animales del horóscopo chino desde la rata
+// (1900, 1912... 1996, 2008, 2020).
+var chineseAnimals = []string{
+	"rata", "buey", "tigre", "conejo", "dragon", "serpiente",
+	"caballo", "cabra", "mono", "gallo", "perro", "cerdo",
+}
+
+// weekdaysES: días de la semana en el orden de time.Weekday.
+var weekdaysES = []string{"domingo", "lunes", "martes", "miercoles", "jueves", "viernes", "sabado"}
+
+// DeriveAttributes calcula los atributos derivados del perfil. Sin año de
+// nacimiento no hay nada que derivar.
+func DeriveAttributes(p Profile) DerivedAttributes {
+	var d DerivedAttributes
+	anio, err := strconv.Atoi(p.Anio)
+	if err != nil {
+		return d
+	}
+	mes, _ := strconv.Atoi(p.Mes)
+	dia, _ := strconv.Atoi(p.Dia)
+
+	// ── Edades ────────────────────────────────────────────────────
+	ref := utils.ReferenceDate()
+	edad := ageAt(anio, mes, dia, ref.Year(), int(ref.Month()), ref.Day())
+	d.Edades = append(d.Edades, edad, edad-1)
+	for _, f := range p.Fechas {
+		fdia, fmes, fanio, ok := f.Parts()
+		if !ok {
+			continue
+		}
+		y, _ := strconv.Atoi(fanio)
+		m, _ := strconv.Atoi(fmes)
+		day, _ := strconv.Atoi(fdia)
+		if e := ageAt(anio, mes, dia, y, m, day); e > 0 {
+			d.Edades = append(d.Edades, e)
+		}
+	}
+
+	// ── Década ────────────────────────────────────────────────────
+	dec := anio / 10 * 10
+	d.Decada = []string{fmt.Sprintf("%02ds", dec%100), fmt.Sprintf("los%02d", dec%100)}
+
+	// ── Horóscopo chino ───────────────────────────────────────────
+	// El año nuevo chino cae entre el 21/01 y el 20/02: en esa ventana
+	// el animal puede ser el del año anterior.
+	animal := chineseAnimals[(anio-1900+1200)%12]
+	prev := chineseAnimals[(anio-1901+1200)%12]
+	switch {
+	case mes == 0 || mes > 2 || (mes == 2 && dia > 20):
+		d.SignoChino = []string{animal}
+	case mes == 1 && dia != 0 && dia < 21:
+		d.SignoChino = []string{prev}
+	case mes == 1 || (dia != 0 && dia < 5):
+		d.SignoChino = []string{prev, animal}
+	default:
+		d.SignoChino = []string{animal, prev}
+	}
+
+	if mes == 0 || dia == 0 {
+		return d
+	}
+
+	// ── Signo y día de la semana ──────────────────────────────────
+	sign := zodiacSigns[len(zodiacSigns)-1] // capricornio hasta el 19/01
+	for _, z := range zodiacSigns {
+		if mes > z.mes || (mes == z.mes && dia >= z.dia) {
+			sign = z
+		}
+	}
+	d.Signo = []string{sign.es, sign.en}
+
+	wd := time.Date(anio, time.Month(mes), dia, 0, 0, 0, 0, time.UTC).Weekday()
+	d.DiaSemana = weekdaysES[wd]
+	return d
+}
+
+// ageAt calcula la edad en una fecha; si falta el día o el mes de alguna
+// de las dos, se cuenta solo la diferencia de años.
+func ageAt(anio, mes, dia, y, m, d int) int {
+	age := y - anio
+	if mes == 0 || dia == 0 || m == 0 || d == 0 {
+		return age
+	}
+	if m < mes || (m == mes && d < dia) {
+		age--
+	}
+	return age
+}
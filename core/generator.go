@@ -0,0 +1,233 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"trickster/utils"
+)
+
+// ================================================================
+// GENERADORES Y REGISTRO DE MÓDULOS
+//
+// Cada módulo del perfil avanzado es un Generator: recibe el contexto
+// del perfil y emite candidatos con su puntaje. StreamProfileWordlist
+// no conoce a ninguno en particular, recorre los registrados en orden.
+//
+// Un módulo propio se agrega sin tocar profiler.go:
+//
+//   func init() {
+//       core.Register(core.NewGenerator("empresa", "palabras de la empresa",
+//           func(ctx *core.GenContext, emit core.Sink) { ... }))
+//   }
+//
+// Las opciones Modules y Disabled de ProfilerOptions permiten elegir
+// qué módulos corren y en qué orden.
+// ================================================================
+
+// Nombres de los módulos incluidos, en el orden en que se registran.
+const (
+	ModuleProfile   = "profile"
+	ModuleLocale    = "locale"
+	ModuleRelatives = "relatives"
+	ModuleDNI       = "dni"
+	ModuleDNIKnown  = "dni-known"
+	ModulePhone     = "phone"
+	ModuleVehicle   = "vehicle"
+)
+
+// GenContext es lo que recibe cada generador: los datos del objetivo y
+// las opciones de la corrida que afectan a la generación.
+type GenContext struct {
+	Profile   Profile
+	Relatives RelativesProfile
+	DNIRange  bool     // generar candidatos de DNI por rango generacional
+	DNIStep   int      // densidad del rango de DNI (nunca 0)
+	IDTable   *IDTable // reemplaza la tabla año → documento del pack (nil = la del pack)
+	Workers   int      // goroutines de generación (0 = una por CPU)
+	Policy    *Policy  // política ya compilada que se aplicará a la salida
+	Locale    *Locale  // pack de localidad del perfil (ver locale.go)
+}
+
+// Generator es un módulo de generación del perfil avanzado.
+// Generate no filtra ni deduplica: de eso se encarga el CandidateSet.
+type Generator interface {
+	Name() string
+	Description() string
+	Generate(ctx *GenContext, emit Sink)
+}
+
+// NewGenerator arma un Generator a partir de una función.
+func NewGenerator(name, description string, fn func(ctx *GenContext, emit Sink)) Generator {
+	return funcGenerator{name, description, fn}
+}
+
+type funcGenerator struct {
+	name, description string
+	fn                func(ctx *GenContext, emit Sink)
+}
+
+func (g funcGenerator) Name() string                        { return g.name }
+func (g funcGenerator) Description() string                 { return g.description }
+func (g funcGenerator) Generate(ctx *GenContext, emit Sink) { g.fn(ctx, emit) }
+
+// registry guarda los generadores en orden de registro.
+var registry []Generator
+
+// Register agrega un generador al registro. Como los registros se hacen
+// en init, un nombre vacío o repetido es un error de programación y entra
+// en pánico.
+func Register(g Generator) {
+	name := g.Name()
+	if name == "" || strings.ContainsAny(name, ", =") {
+		panic(fmt.Sprintf("core: nombre de generador inválido %q", name))
+	}
+	if LookupGenerator(name) != nil {
+		panic("core: generador registrado dos veces: " + name)
+	}
+	registry = append(registry, g)
+}
+
+// Generators devuelve los generadores registrados, en orden de registro.
+func Generators() []Generator {
+	return append([]Generator(nil), registry...)
+}
+
+// GeneratorNames devuelve los nombres de los generadores registrados.
+func GeneratorNames() []string {
+	names := make([]string, len(registry))
+	for i, g := range registry {
+		names[i] = g.Name()
+	}
+	return names
+}
+
+// LookupGenerator busca un generador por nombre; nil si no existe.
+func LookupGenerator(name string) Generator {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, g := range registry {
+		if g.Name() == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// SelectGenerators resuelve qué módulos corren y en qué orden: los de
+// modules en ese orden (o todos los registrados si está vacío), menos
+// los de disabled.
+func SelectGenerators(modules, disabled []string) ([]Generator, error) {
+	selected := registry
+	if len(modules) > 0 {
+		selected = nil
+		for _, name := range modules {
+			g := LookupGenerator(name)
+			if g == nil {
+				return nil, unknownModule(name)
+			}
+			selected = append(selected, g)
+		}
+	}
+
+	off := make(map[string]bool, len(disabled))
+	for _, name := range disabled {
+		if LookupGenerator(name) == nil {
+			return nil, unknownModule(name)
+		}
+		off[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	var result []Generator
+	seen := make(map[string]bool)
+	for _, g := range selected {
+		if !off[g.Name()] && !seen[g.Name()] {
+			seen[g.Name()] = true
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func unknownModule(name string) error {
+	return fmt.Errorf("módulo desconocido %q (usar %s)", name, strings.Join(GeneratorNames(), ", "))
+}
+
+// ── Módulos incluidos ─────────────────────────────────────────────
+
+func init() {
+	Register(NewGenerator(ModuleProfile, "formas, sufijos y combinaciones de los datos del perfil",
+		func(ctx *GenContext, emit Sink) {
+			// Con una política compleja sus formas van primero: sin -rank ni
+			// -limit el orden de generación es el de la salida
+			if ctx.Policy.Complex() {
+				StreamComplexForms(ctx.Profile, emit)
+			}
+			StreamFromProfile(ctx.Profile, ctx.Workers, emit)
+		}))
+
+	Register(NewGenerator(ModuleLocale, "vocabulario, clubes y documento del pack de localidad",
+		func(ctx *GenContext, emit Sink) {
+			StreamLocalePatterns(ctx.Locale, ctx.Profile, emit)
+		}))
+
+	Register(NewGenerator(ModuleRelatives, "familiares y mascotas encontrados por OSINT",
+		func(ctx *GenContext, emit Sink) {
+			StreamFromRelatives(ctx.Relatives, ctx.Profile, ctx.Workers, emit)
+		}))
+
+	Register(NewGenerator(ModuleDNI, "documento estimado por rango generacional (requiere año y documento desconocido)",
+		func(ctx *GenContext, emit Sink) {
+			p := ctx.Profile
+			if !ctx.DNIRange || p.DNI != "" || p.Anio == "" {
+				return
+			}
+			l := ctx.Locale
+			table := l.IDTable
+			if ctx.IDTable != nil {
+				table = ctx.IDTable
+			}
+			if table == nil && l.IDRange == nil {
+				utils.Warn(fmt.Sprintf("El pack %s no tiene rango de documentos por año; se omite el módulo dni.", l.Code))
+				return
+			}
+			birthYear := 0
+			fmt.Sscanf(p.Anio, "%d", &birthYear)
+			if birthYear <= 0 {
+				return
+			}
+			utils.Info(fmt.Sprintf("Generando candidatos de DNI por rango generacional (step=%d)...", ctx.DNIStep))
+			if table != nil {
+				streamIDTable(table, birthYear, l.idFormats(), l.RelatedIDs, p.Nombre, ctx.DNIStep, ctx.Workers, emit)
+			} else {
+				min, max := l.IDRange(birthYear)
+				streamDNIRange(min, max, l.idFormats(), l.RelatedIDs, p.Nombre, ctx.DNIStep, ctx.Workers, emit)
+			}
+		}))
+
+	Register(NewGenerator(ModuleDNIKnown, "variantes del documento conocido (DNI, RUT...)",
+		func(ctx *GenContext, emit Sink) {
+			p := ctx.Profile
+			if p.DNI != "" {
+				ctx.Locale.knownID()(p.DNI, p.Nombre, p.Apellido, p.Anio, emit)
+			}
+		}))
+
+	Register(NewGenerator(ModulePhone, "celular y teléfono fijo en sus formas locales e internacionales",
+		func(ctx *GenContext, emit Sink) {
+			p := ctx.Profile
+			if p.Celular != "" {
+				StreamPhoneVariants(ctx.Locale, p.Celular, true, p.Nombre, p.Apellido, p.Anio, emit)
+			}
+			if p.Telefono != "" {
+				StreamPhoneVariants(ctx.Locale, p.Telefono, false, p.Nombre, p.Apellido, p.Anio, emit)
+			}
+		}))
+
+	Register(NewGenerator(ModuleVehicle, "marca, modelo y patente del vehículo",
+		func(ctx *GenContext, emit Sink) {
+			p := ctx.Profile
+			if p.Patente != "" && ctx.Locale.Code == DefaultLocale && PlateKind(p.Patente) == "" {
+				utils.Warn(fmt.Sprintf("La patente %q no tiene formato argentino (AAA123, AA123BB); se separa igual en letras y números.", p.Patente))
+			}
+			StreamVehicleVariants(p, emit)
+		}))
+}
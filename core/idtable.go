@@ -0,0 +1,178 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"trickster/utils"
+)
+
+// ================================================================
+// TABLAS DE DOCUMENTOS POR AÑO DE NACIMIENTO
+//
+// Para los países donde el número de documento es correlativo, una
+// IDTable guarda por año la mediana y la dispersión de los documentos
+// emitidos a los nacidos ese año. Se carga de un CSV simple:
+//
+//   # comentario
+//   anio,mediana,dispersion
+//   1990,39800000,1260000
+//
+// En lugar de recorrer un rango de forma uniforme, IDSamples toma los
+// números según los cuantiles de una normal: muchos cerca de la mediana
+// y pocos en las colas. Con la misma cantidad de candidatos cubre mucho
+// mejor la zona donde realmente está el documento.
+// ================================================================
+
+// idTableSigmas es cuántas dispersiones a cada lado de la mediana cubre IDTable.Range.
+const idTableSigmas = 3
+
+// IDTableRow es una fila de la tabla: año, mediana y desvío estimado.
+type IDTableRow struct {
+	Year   int
+	Median int
+	Spread int
+}
+
+// IDTable es la relación año de nacimiento → documento, ordenada por año.
+type IDTable struct {
+	rows []IDTableRow
+}
+
+// LoadIDTable lee una tabla desde un archivo CSV. Con -id-table (o la
+// pregunta del modo interactivo) reemplaza a la tabla del pack, por
+// ejemplo para actualizar data/dni_ar.csv sin recompilar.
+func LoadIDTable(path string) (*IDTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer la tabla de documentos: %w", err)
+	}
+	defer f.Close()
+	return ParseIDTable(f)
+}
+
+// askIDTable pregunta por una tabla propia; Enter = la del pack.
+func askIDTable() *IDTable {
+	for {
+		path := utils.AskOptional("Tabla año → documento propia (.csv, Enter = la del pack)")
+		if path == "" {
+			return nil
+		}
+		t, err := LoadIDTable(path)
+		if err == nil {
+			return t
+		}
+		utils.Error(err.Error())
+	}
+}
+
+// ParseIDTable lee una tabla en formato anio,mediana,dispersion. Las
+// líneas vacías, las que empiezan con # y la cabecera se ignoran.
+func ParseIDTable(r io.Reader) (*IDTable, error) {
+	t := &IDTable{}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") || text == "anio,mediana,dispersion" {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("tabla de documentos, línea %d: se esperaba anio,mediana,dispersion", line)
+		}
+		var v [3]int
+		for i, f := range fields {
+			n, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("tabla de documentos, línea %d: valor inválido %q", line, f)
+			}
+			v[i] = n
+		}
+		t.rows = append(t.rows, IDTableRow{Year: v[0], Median: v[1], Spread: v[2]})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.rows) == 0 {
+		return nil, fmt.Errorf("tabla de documentos vacía")
+	}
+	sort.SliceStable(t.rows, func(i, j int) bool { return t.rows[i].Year < t.rows[j].Year })
+	return t, nil
+}
+
+// mustParseIDTable es ParseIDTable para las tablas embebidas, que se
+// validan al compilar el pack: un error es un error de programación.
+func mustParseIDTable(data string) *IDTable {
+	t, err := ParseIDTable(strings.NewReader(data))
+	if err != nil {
+		panic("core: " + err.Error())
+	}
+	return t
+}
+
+// Estimate devuelve la mediana y la dispersión para birthYear. Entre dos
+// años de la tabla se interpola; fuera de la tabla se usa el extremo.
+func (t *IDTable) Estimate(birthYear int) (median, spread int) {
+	rows := t.rows
+	i := sort.Search(len(rows), func(i int) bool { return rows[i].Year >= birthYear })
+	switch {
+	case i == len(rows):
+		return rows[i-1].Median, rows[i-1].Spread
+	case rows[i].Year == birthYear || i == 0:
+		return rows[i].Median, rows[i].Spread
+	}
+	a, b := rows[i-1], rows[i]
+	f := float64(birthYear-a.Year) / float64(b.Year-a.Year)
+	median = a.Median + int(f*float64(b.Median-a.Median))
+	spread = a.Spread + int(f*float64(b.Spread-a.Spread))
+	return median, spread
+}
+
+// Range devuelve el rango [min, max] que cubre casi todos los documentos
+// de birthYear: mediana ± 3 dispersiones.
+func (t *IDTable) Range(birthYear int) (min, max int) {
+	median, spread := t.Estimate(birthYear)
+	min = median - idTableSigmas*spread
+	if min < 1 {
+		min = 1
+	}
+	return min, median + idTableSigmas*spread
+}
+
+// IDSamples devuelve, en orden ascendente y sin repetidos, tantos
+// números como tendría un recorrido uniforme de Range(birthYear) de a
+// step, distribuidos según una normal alrededor de la mediana. Cada número
+// viene con su densidad relativa (1 en la mediana) para ponderarlo.
+func (t *IDTable) IDSamples(birthYear, step int) (docs []int, density []float64) {
+	if step <= 0 {
+		step = 1000
+	}
+	median, spread := t.Estimate(birthYear)
+	min, max := t.Range(birthYear)
+	count := (max-min)/step + 1
+	if spread == 0 {
+		return []int{median}, []float64{1}
+	}
+
+	for i := 0; i < count; i++ {
+		// Cuantil (i+0.5)/count de la normal, acotado al rango
+		z := math.Sqrt2 * math.Erfinv(2*(float64(i)+0.5)/float64(count)-1)
+		if z < -idTableSigmas {
+			z = -idTableSigmas
+		} else if z > idTableSigmas {
+			z = idTableSigmas
+		}
+		doc := median + int(math.Round(z*float64(spread)))
+		if doc < min || (len(docs) > 0 && doc <= docs[len(docs)-1]) {
+			continue
+		}
+		docs = append(docs, doc)
+		density = append(density, math.Exp(-z*z/2))
+	}
+	return docs, density
+}
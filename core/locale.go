@@ -0,0 +1,261 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"trickster/transforms"
+)
+
+// ================================================================
+// PACKS DE LOCALIDAD
+//
+// Cada país donde auditamos tiene su vocabulario, sus clubes, su
+// documento de identidad y sus costumbres al armar contraseñas. Un
+// Locale agrupa todo eso y StreamLocalePatterns lo aplica sobre el
+// perfil; el pack se elige por perfil con Profile.Locale.
+//
+// Argentina (Arg.go) es el pack por defecto; el resto está en
+// locales.go. Un pack nuevo solo necesita llamar a RegisterLocale.
+// ================================================================
+
+// DefaultLocale es el pack que se usa si el perfil no indica ninguno.
+const DefaultLocale = "ar"
+
+// Locale agrupa los patrones locales de un país.
+type Locale struct {
+	Code string // código ISO 3166-1 alfa-2 en minúsculas (ar, es, mx...)
+	Name string
+
+	Phrases []string // jerga, términos afectivos y palabras frecuentes en leaks locales
+	Clubs   []string // clubes de fútbol, ordenados por cantidad de hinchas
+
+	// Costumbres locales; nil = no se aplican
+	RepeatPatterns    func(token, anio, anioCorto string) []string // año duplicado, ej: juan19901990
+	LetterDuplication func(token string) []string                  // carlosss, carlos!!!
+
+	// NationalID genera candidatos a partir del documento del perfil
+	// (CUIL en Argentina, cédula con dígito verificador en Uruguay...);
+	// nil = solo el documento tal cual, que ya cubren profiler.go y el
+	// módulo dni-known.
+	NationalID func(p Profile, emit Sink)
+
+	// IDTable da la mediana y dispersión de los documentos emitidos por
+	// año de nacimiento; el módulo dni concentra los candidatos cerca de
+	// la mediana. Si es nil se usa IDRange.
+	IDTable *IDTable
+
+	// IDRange estima el rango de documentos emitidos para un año de
+	// nacimiento, recorrido de forma uniforme por el módulo dni; si
+	// tampoco está, el módulo no aplica.
+	IDRange func(birthYear int) (min, max int)
+
+	// RelatedIDs devuelve documentos derivados de un número del rango
+	// (CUIL válidos en Argentina); nil = solo el número.
+	RelatedIDs func(doc int) []string
+
+	// IDFormats da las formas escritas de un número de documento (RUT con
+	// verificador en Chile...); nil = DNIFormats.
+	IDFormats func(doc int) []string
+
+	// PhoneFormats da las formas escritas de un teléfono del perfil
+	// (área, 15, +54 9... en Argentina); mobile indica que es un celular.
+	// nil = genericPhoneFormats.
+	PhoneFormats func(raw string, mobile bool) []string
+
+	// KnownID genera las variantes del documento conocido del perfil, usado
+	// por el módulo dni-known; nil = StreamDNIVariantsFromKnown.
+	KnownID func(doc, nombre, apellido, anio string, emit Sink)
+
+	// ValidID revisa el documento que carga el usuario (largo, dígito
+	// verificador) y explica qué está mal; nil = genericValidID.
+	ValidID func(doc string) error
+
+	// NicknameLangs son los idiomas del diccionario de apodos que se usan,
+	// en orden de preferencia (ver nicknames.go); nil = solo español.
+	NicknameLangs []string
+}
+
+var locales = make(map[string]*Locale)
+
+// RegisterLocale agrega un pack. Se llama desde init; un código repetido
+// es un error de programación y entra en pánico.
+func RegisterLocale(l *Locale) {
+	code := strings.ToLower(l.Code)
+	if _, dup := locales[code]; dup || code == "" {
+		panic(fmt.Sprintf("core: pack de localidad inválido o repetido %q", code))
+	}
+	locales[code] = l
+}
+
+// LookupLocale devuelve el pack del código indicado ("" = DefaultLocale).
+func LookupLocale(code string) (*Locale, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		code = DefaultLocale
+	}
+	if l, ok := locales[code]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("pack de localidad desconocido %q (usar %s)", code, strings.Join(LocaleCodes(), ", "))
+}
+
+// LocaleCodes devuelve los códigos disponibles ordenados alfabéticamente.
+func LocaleCodes() []string {
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// GenerateLocalePatterns es la versión en memoria de StreamLocalePatterns, sin duplicados.
+func GenerateLocalePatterns(l *Locale, p Profile) []string {
+	return collect(func(emit Sink) { StreamLocalePatterns(l, p, emit) })
+}
+
+// StreamLocalePatterns genera los candidatos específicos del pack l.
+// Se ejecuta después de StreamFromProfile para agregar el vocabulario
+// local sin duplicar la lógica base.
+func StreamLocalePatterns(l *Locale, p Profile, emit Sink) {
+	add := emit // longitud y duplicados se filtran en CandidateSet
+
+	n := ""
+	nc := ""
+	if p.Nombre != "" {
+		n = lowerTrim(p.Nombre)
+		nc = capFirst(n)
+	}
+
+	// ── 1. Frases locales combinadas con el nombre ────────────────
+	for k, phrase := range l.Phrases {
+		w := weightLocal * 0.6 * freq(k)
+		add(phrase, w)
+		if n != "" {
+			add(n+phrase, w*weightNombre*wLower)
+			add(nc+phrase, w*weightNombre*wCap)
+			add(phrase+n, w*weightNombre*0.8*wLower)
+			add(phrase+nc, w*weightNombre*0.8*wCap)
+		}
+		if p.Anio != "" {
+			add(phrase+p.Anio, w*weightAnio)
+			add(phrase+p.AnioCorto, w*weightAnio*0.8)
+		}
+	}
+
+	// ── 2. Clubes de fútbol locales × año ─────────────────────────
+	// Si el objetivo ingresó equipo, ya está cubierto en profiler.go.
+	// Acá cubrimos los más frecuentes sin importar el equipo declarado.
+	for k, club := range l.Clubs {
+		w := weightLocal * freq(k)
+		add(club, w*wLower)
+		add(capFirst(club), w*wCap)
+		if p.Anio != "" {
+			add(club+p.Anio, w*weightAnio*wLower)
+			add(capFirst(club)+p.Anio, w*weightAnio*wCap)
+			add(club+p.AnioCorto, w*weightAnio*0.8)
+		}
+		if n != "" {
+			add(n+club, w*weightNombre*0.6)
+			add(club+n, w*weightNombre*0.5)
+			add(nc+capFirst(club), w*weightNombre*0.5*wCap)
+		}
+		for j, num := range []string{"1", "10", "9", "11", "123"} {
+			add(club+num, w*0.8*freq(j))
+		}
+	}
+
+	// ── 3. Patrones de año duplicado (comportamiento local) ───────
+	if l.RepeatPatterns != nil && p.Anio != "" {
+		if n != "" {
+			w := weightLocal * weightNombre * 0.5
+			for _, pat := range l.RepeatPatterns(n, p.Anio, p.AnioCorto) {
+				add(pat, w*wLower)
+			}
+			for _, pat := range l.RepeatPatterns(nc, p.Anio, p.AnioCorto) {
+				add(pat, w*wCap)
+			}
+		}
+		if p.Apellido != "" {
+			aLow := lowerTrim(p.Apellido)
+			w := weightLocal * weightApellido * 0.5
+			for _, pat := range l.RepeatPatterns(aLow, p.Anio, p.AnioCorto) {
+				add(pat, w*wLower)
+			}
+			for _, pat := range l.RepeatPatterns(capFirst(aLow), p.Anio, p.AnioCorto) {
+				add(pat, w*wCap)
+			}
+		}
+	}
+
+	// ── 4. Duplicación de letras y puntuación local ───────────────
+	if l.LetterDuplication != nil {
+		for _, base := range []string{n, nc} {
+			if base == "" {
+				continue
+			}
+			for _, pat := range l.LetterDuplication(base) {
+				add(pat, weightLocal*weightNombre*0.4)
+			}
+		}
+		if p.Apellido != "" {
+			for _, base := range []string{lowerTrim(p.Apellido), capFirst(lowerTrim(p.Apellido))} {
+				for _, pat := range l.LetterDuplication(base) {
+					add(pat, weightLocal*weightApellido*0.4)
+				}
+			}
+		}
+	}
+
+	// ── 5. Documento nacional ─────────────────────────────────────
+	if l.NationalID != nil {
+		l.NationalID(p, add)
+	}
+}
+
+// idFormats devuelve IDFormats o, si el pack no lo define, DNIFormats.
+func (l *Locale) idFormats() func(doc int) []string {
+	if l.IDFormats != nil {
+		return l.IDFormats
+	}
+	return DNIFormats
+}
+
+// knownID devuelve KnownID o, si el pack no lo define, StreamDNIVariantsFromKnown.
+func (l *Locale) knownID() func(doc, nombre, apellido, anio string, emit Sink) {
+	if l.KnownID != nil {
+		return l.KnownID
+	}
+	return StreamDNIVariantsFromKnown
+}
+
+// ── helpers ──────────────────────────────────────────────────────
+
+// cleanDocument quita puntos, guiones y espacios de un número de documento.
+func cleanDocument(doc string) string {
+	for _, r := range []string{".", "-", " "} {
+		doc = strings.ReplaceAll(doc, r, "")
+	}
+	return doc
+}
+
+// dottedThousands agrupa los dígitos de a tres con puntos: 30123456 → 30.123.456
+func dottedThousands(digits string) string {
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func lowerTrim(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func capFirst(s string) string {
+	return transforms.Capitalize(s)
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"strconv"
+)
+
+// ================================================================
+// PACKS DE LOCALIDAD: ESPAÑA, MÉXICO, CHILE, URUGUAY, COLOMBIA, BRASIL
+//
+// Mismo criterio que el pack argentino (Arg.go): jerga y términos
+// afectivos que aparecen en leaks de cada país, clubes ordenados por
+// cantidad de hinchas y la lógica del documento nacional cuando tiene
+// una forma escrita distinta del número pelado.
+// ================================================================
+
+func init() {
+	RegisterLocale(&Locale{
+		Code: "es",
+		Name: "España",
+		Phrases: []string{
+			"tio", "tia", "guay", "mola", "vale", "hostia", "joder",
+			"cabron", "chaval", "chavala", "colega", "tronco", "majo", "maja",
+			"amor", "cariño", "carino", "cielo", "vida", "mivida", "corazon",
+			"gordi", "nene", "nena", "bebe", "princesa", "guapa", "guapo",
+			"madrid", "barcelona", "sevilla", "valencia", "españa", "espana",
+			"password", "contraseña", "contrasena", "clave", "miclave",
+		},
+		Clubs: []string{
+			"realmadrid", "madrid", "barcelona", "barca", "atletico", "atleti",
+			"sevilla", "betis", "valencia", "athletic", "realsociedad",
+			"villarreal", "celta", "deportivo", "depor", "espanyol",
+			"zaragoza", "osasuna", "malaga", "sporting",
+		},
+		// DNI/NIE con letra de control (ver NIF-intel.go)
+		IDFormats: NIFFormats,
+		KnownID:   StreamNIFVariantsFromKnown,
+		ValidID:   nifValidID,
+	})
+
+	RegisterLocale(&Locale{
+		Code: "mx",
+		Name: "México",
+		Phrases: []string{
+			"chido", "chida", "wey", "guey", "carnal", "compa", "cuate",
+			"neta", "chingon", "chingona", "padre", "orale", "mijo", "mija",
+			"amor", "miamor", "corazon", "vida", "mivida", "cielo", "bebe",
+			"gordo", "gorda", "flaco", "flaca", "chaparro", "chaparra", "princesa",
+			"mexico", "cdmx", "tequila", "guadalupe", "lupita",
+			"password", "contraseña", "contrasena", "clave", "miclave",
+		},
+		Clubs: []string{
+			"america", "chivas", "cruzazul", "pumas", "tigres", "monterrey",
+			"rayados", "toluca", "santos", "leon", "pachuca", "atlas",
+			"puebla", "necaxa", "queretaro", "tijuana", "xolos",
+		},
+		// CURP y RFC derivados del perfil (ver CURP-intel.go)
+		NationalID: streamCURPRFC,
+	})
+
+	RegisterLocale(&Locale{
+		Code: "cl",
+		Name: "Chile",
+		Phrases: []string{
+			"weon", "wn", "po", "cachai", "bacan", "fome", "pololo", "polola",
+			"cabro", "cabra", "flaite", "luca", "chela", "pega",
+			"amor", "miamor", "corazon", "vida", "mivida", "cielo", "bebe",
+			"gordo", "gorda", "guatón", "guaton", "princesa",
+			"chile", "santiago", "valpo", "conce",
+			"password", "contraseña", "contrasena", "clave", "miclave",
+		},
+		Clubs: []string{
+			"colocolo", "colo", "udechile", "lau", "universidaddechile",
+			"catolica", "uc", "wanderers", "everton", "cobreloa",
+			"palestino", "audax", "huachipato", "ohiggins", "union",
+		},
+		// RUT con dígito verificador (ver RUT-intel.go)
+		IDRange:   rutRangeForBirthYear,
+		IDFormats: RUTFormats,
+		KnownID:   StreamRUTVariantsFromKnown,
+		ValidID:   rutValidID,
+	})
+
+	RegisterLocale(&Locale{
+		Code: "uy",
+		Name: "Uruguay",
+		Phrases: []string{
+			"bo", "ta", "botija", "gurí", "guri", "gurisa", "barbaro",
+			"bárbaro", "tremendo", "pila", "tranqui", "mate", "celeste",
+			"amor", "miamor", "corazon", "vida", "mivida", "cielo", "bebe",
+			"gordo", "gorda", "flaco", "flaca", "nena", "nene",
+			"uruguay", "montevideo", "charrua", "garra",
+			"password", "contraseña", "contrasena", "clave", "miclave",
+		},
+		Clubs: []string{
+			"penarol", "peñarol", "nacional", "bolso", "manya", "defensor",
+			"danubio", "wanderers", "liverpool", "fenix", "cerro",
+			"riverplate", "racing", "progreso",
+		},
+		// Misma costumbre rioplatense que en Argentina
+		RepeatPatterns:    ArgRepeatPatterns,
+		LetterDuplication: ArgLetterDuplication,
+		NationalID:        streamCedulaUY,
+	})
+
+	RegisterLocale(&Locale{
+		Code: "co",
+		Name: "Colombia",
+		Phrases: []string{
+			"parce", "parcero", "parcera", "bacano", "bacana", "chimba",
+			"berraco", "berraca", "marica", "llave", "mono", "mona", "pelao",
+			"amor", "miamor", "corazon", "vida", "mivida", "cielo", "bebe",
+			"gordo", "gorda", "flaco", "flaca", "princesa", "reina",
+			"colombia", "bogota", "medellin", "cali", "paisa", "costeño",
+			"password", "contraseña", "contrasena", "clave", "miclave",
+		},
+		Clubs: []string{
+			"millonarios", "nacional", "atleticonacional", "america", "americadecali",
+			"junior", "santafe", "independientesantafe", "medellin", "dim",
+			"cali", "deportivocali", "oncecaldas", "tolima", "bucaramanga",
+		},
+		NationalID: streamCedulaCO,
+	})
+
+	RegisterLocale(&Locale{
+		Code: "br",
+		Name: "Brasil",
+		Phrases: []string{
+			"amor", "meuamor", "vida", "minhavida", "querida", "querido",
+			"gatinha", "gatinho", "linda", "lindo", "princesa", "bebe", "fofa",
+			"mozao", "cara", "mano", "parceiro", "beleza", "saudade", "deus",
+			"jesus", "brasil", "saopaulo", "rio", "bahia", "flamengo",
+			"senha", "minhasenha", "password", "mudar", "trocar",
+		},
+		Clubs: []string{
+			"flamengo", "mengao", "corinthians", "timao", "saopaulo", "palmeiras",
+			"verdao", "vasco", "cruzeiro", "gremio", "atleticomineiro", "galo",
+			"internacional", "inter", "santos", "fluminense", "botafogo", "bahia",
+		},
+		// CPF con verificadores (ver CPF-intel.go)
+		KnownID:       StreamCPFVariantsFromKnown,
+		ValidID:       cpfValidID,
+		NicknameLangs: []string{"pt", "es", "en"},
+	})
+}
+
+// streamCedulaUY: la cédula uruguaya tiene 7 dígitos y un dígito
+// verificador, y se escribe 1.234.567-2. El verificador se calcula con
+// los pesos 2987634 módulo 10.
+func streamCedulaUY(p Profile, add Sink) {
+	ci := cleanDocument(p.DNI)
+	if _, err := strconv.Atoi(ci); err != nil || len(ci) < 6 || len(ci) > 8 {
+		return
+	}
+
+	// Con 8 dígitos el último ya es el verificador
+	body := ci
+	if len(ci) == 8 {
+		body = ci[:7]
+	}
+	for len(body) < 7 {
+		body = "0" + body
+	}
+	dv := strconv.Itoa(cedulaUYCheckDigit(body))
+	trimmed := trimLeadingZeros(body)
+
+	w := weightDNI
+	add(trimmed+dv, w)
+	add(trimmed+"-"+dv, w*0.8)
+	add(dottedThousands(trimmed)+"-"+dv, w*0.6)
+	add(dottedThousands(trimmed), w*0.4)
+	if p.Nombre != "" {
+		n := lowerTrim(p.Nombre)
+		add(n+trimmed+dv, w*weightNombre*0.5*wLower)
+		add(capFirst(n)+trimmed+dv, w*weightNombre*0.5*wCap)
+	}
+}
+
+// cedulaUYCheckDigit calcula el dígito verificador de una cédula de 7 dígitos.
+func cedulaUYCheckDigit(body string) int {
+	weights := []int{2, 9, 8, 7, 6, 3, 4}
+	sum := 0
+	for i, r := range body {
+		sum += int(r-'0') * weights[i]
+	}
+	return (10 - sum%10) % 10
+}
+
+// streamCedulaCO: la cédula colombiana no tiene verificador, pero se
+// escribe con puntos de miles (1.020.304.050) y así aparece en contraseñas.
+func streamCedulaCO(p Profile, add Sink) {
+	cc := cleanDocument(p.DNI)
+	if _, err := strconv.Atoi(cc); err != nil || cc == "" {
+		return
+	}
+	add(dottedThousands(cc), weightDNI*0.6)
+	if len(cc) > 4 {
+		// Los últimos 4 dígitos, muy usados como PIN
+		add(cc[len(cc)-4:], weightDNI*0.3)
+	}
+}
+
+// trimLeadingZeros quita los ceros a la izquierda, dejando al menos un dígito.
+func trimLeadingZeros(digits string) string {
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return digits
+	}
+	return strconv.Itoa(n)
+}
@@ -7,10 +7,19 @@ import (
 	"trickster/utils"
 )
 
+// MasksOptions agrupa las respuestas del Módulo 1 para poder ejecutarlo
+// sin prompts (por ejemplo desde la CLI).
+type MasksOptions struct {
+	InputPath  string
+	OutputPath string
+	Dedup      utils.DedupConfig
+	Policy     *Policy // nil = sin filtro
+}
+
 // RunMasks es el punto de entrada del Módulo 1.
 // Toma una wordlist existente y genera variantes con máscaras alfanuméricas.
 func RunMasks() {
-	fmt.Println("\n\033[1m[ MÓDULO 1 - MÁSCARAS DESDE WORDLIST ]\033[0m\n")
+	fmt.Print("\n\033[1m[ MÓDULO 1 - MÁSCARAS DESDE WORDLIST ]\033[0m\n\n")
 	utils.Info("Este módulo toma tu wordlist y genera cientos de variantes automáticamente.")
 	fmt.Println()
 
@@ -25,26 +34,81 @@ func RunMasks() {
 	}
 	utils.Success(fmt.Sprintf("Cargadas %d palabras base.", len(words)))
 
-	// 3. Generar variantes para cada palabra
+	// 3. Pedir ruta de salida
+	dedup := askDedupConfig()
+	policy := askPolicy(nil)
+	outputPath := utils.AskStringRequired("Ruta de salida para la wordlist generada (ej: /home/user/output.txt)")
+
+	// 4. Generar variantes para cada palabra (sin duplicados) y escribirlas
 	utils.Info("Generando variantes...")
-	var allVariants []string
+	if err := writeMasks(words, dedup, policy, outputPath); err != nil {
+		utils.Error("Error al generar la wordlist: " + err.Error())
+	}
+}
 
-	for _, word := range words {
-		variants := transforms.AllVariants(word)
-		allVariants = append(allVariants, variants...)
+// RunMasksWith ejecuta el Módulo 1 con opciones ya resueltas, sin preguntar nada.
+func RunMasksWith(opts MasksOptions) error {
+	if err := opts.Policy.Compile(); err != nil {
+		return err
+	}
+	words, err := utils.ReadWordlistFile(opts.InputPath)
+	if err != nil {
+		return err
 	}
+	utils.Success(fmt.Sprintf("Cargadas %d palabras base.", len(words)))
 
-	// 4. Eliminar duplicados
-	allVariants = utils.Deduplicate(allVariants)
+	return writeMasks(words, opts.Dedup, opts.Policy, opts.OutputPath)
+}
 
-	// 5. Pedir ruta de salida
-	outputPath := utils.AskStringRequired("Ruta de salida para la wordlist generada (ej: /home/user/output.txt)")
+// writeMasks genera las variantes y las escribe en outputPath a medida
+// que salen del deduplicador (ver streamWordlist): con bloom o disk la
+// memoria no crece con la cantidad de variantes.
+func writeMasks(words []string, dedup utils.DedupConfig, policy *Policy, outputPath string) error {
+	count, err := streamWordlist(outputPath, func(emit Sink) error {
+		return StreamMasks(words, dedup, policy, func(s string) { emit(s, 0) })
+	})
+	if err != nil {
+		return err
+	}
+	output.PrintStats(outputPath, count)
+	return nil
+}
 
-	// 6. Escribir al archivo
-	if err := output.WriteWordlist(allVariants, outputPath); err != nil {
-		utils.Error("Error al guardar: " + err.Error())
-		return
+// GenerateMasks es la versión en memoria de StreamMasks.
+func GenerateMasks(words []string, dedup utils.DedupConfig, policy *Policy) ([]string, error) {
+	var result []string
+	err := StreamMasks(words, dedup, policy, func(s string) { result = append(result, s) })
+	return result, err
+}
+
+// StreamMasks aplica transforms.AllVariants a cada palabra, descarta lo
+// que no cumple la política (ya compilada, o nil) y pasa cada variante
+// nueva a emit, eliminando duplicados con el backend indicado. Con el
+// backend disk las variantes salen recién al final, ordenadas.
+func StreamMasks(words []string, dedup utils.DedupConfig, policy *Policy, emit func(string)) error {
+	d, err := utils.NewDeduper(dedup)
+	if err != nil {
+		return err
+	}
+	for _, word := range words {
+		for _, v := range transforms.AllVariants(word) {
+			if !policy.Allows(v) {
+				continue
+			}
+			if err := d.Add(v, emit); err != nil {
+				return err
+			}
+		}
 	}
+	return d.Flush(emit)
+}
 
-	output.PrintStats(outputPath, len(allVariants))
+// saveWordlist escribe la wordlist y muestra el resumen final.
+// Es el último paso común a los tres módulos.
+func saveWordlist(words []string, outputPath string) error {
+	if err := output.WriteWordlist(words, outputPath); err != nil {
+		return err
+	}
+	output.PrintStats(outputPath, len(words))
+	return nil
 }
@@ -1,164 +1,185 @@
 package core
 
 import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"sort"
 	"strings"
 	"trickster/transforms"
 )
 
 // ================================
 // DICCIONARIO DE APODOS
-// Más de 100 nombres comunes en español con sus apodos reales
+// Nombres comunes con sus apodos reales, en data/nicknames/<idioma>.csv
+// (español, inglés, italiano y portugués). Cada entrada lleva el sexo y
+// el idioma; el pack de localidad elige qué idiomas se usan (ver
+// Locale.NicknameLangs). Con LoadNicknameFile se suman archivos propios
+// en el mismo formato, sin recompilar.
 // ================================
 
-var nicknameDict = map[string][]string{
-	// ---- Masculinos ----
-	"alejandro": {"ale", "alex", "alejo", "alejan", "xander", "jandro"},
-	"alberto":   {"beto", "alber", "al", "bert"},
-	"alfredo":   {"fredo", "fred", "alfre", "alfi"},
-	"andres":    {"andy", "andre", "andresito", "andes"},
-	"antonio":   {"tony", "toni", "anto", "toño", "antoñito"},
-	"ariel":     {"ari", "arielito"},
-	"augusto":   {"gus", "augus", "tito"},
-	"benjamin":  {"benja", "benji", "ben"},
-	"carlos":    {"cali", "carl", "carli", "cha", "charly", "carlitos", "carly"},
-	"christian": {"chris", "cris", "chri", "christianito"},
-	"claudio":   {"clau", "claudito"},
-	"cristian":  {"cris", "chris", "cristi", "cristiano"},
-	"daniel":    {"dani", "dan", "danny", "danielito"},
-	"david":     {"dav", "davit", "davidcho", "dave"},
-	"diego":     {"die", "diegui", "dieghito", "diegote"},
-	"eduardo":   {"edu", "eduar", "eddie", "lalo", "edy"},
-	"emilio":    {"emi", "emilito", "mil"},
-	"enrique":   {"quique", "kike", "enri", "henry"},
-	"ernesto":   {"nesto", "ernes", "nestor", "ernie"},
-	"esteban":   {"este", "esteba", "teba", "steve"},
-	"ezequiel":  {"eze", "ezequielito", "zeke"},
-	"facundo":   {"facu", "faku", "facun"},
-	"federico":  {"fede", "fed", "freddy", "fedo"},
-	"felipe":    {"feli", "pipe", "pippo", "pipo"},
-	"fernando":  {"fer", "fercho", "nando", "fernan"},
-	"francisco": {"fran", "pancho", "cisco", "franci", "kiko", "paco", "francho"},
-	"gabriel":   {"gabi", "gabo", "gabri", "gabriel"},
-	"gonzalo":   {"gonza", "gonzi", "gonzo", "gon"},
-	"guillermo": {"guille", "willy", "will", "guillerme", "memo"},
-	"gustavo":   {"gus", "gusti", "tavo", "gusta"},
-	"hernando":  {"hernan", "hernie", "nando"},
-	"horacio":   {"hora", "horacito", "hor"},
-	"hugo":      {"hugito", "huguito", "hug"},
-	"ignacio":   {"nacho", "igna", "iñaki", "nachito"},
-	"ivan":      {"iva", "ivancho", "ivanito"},
-	"javier":    {"javi", "xavi", "javiercho", "jabier"},
-	"jesus":     {"jesusito", "chucho", "chuy", "jesu"},
-	"jorge":     {"jorgito", "jorgi", "george", "jor"},
-	"jose":      {"pepe", "josecito", "chepe", "joselito", "josepe"},
-	"juan":      {"juancho", "juancito", "juani", "johnny", "juanito"},
-	"julian":    {"juli", "juliancho", "juliancito"},
-	"leandro":   {"lea", "lean", "leandrito", "lechuga"},
-	"leonardo":  {"leo", "leon", "leonardito", "lenny"},
-	"lorenzo":   {"loren", "lorencito", "renzo"},
-	"lucas":     {"luca", "luquitas", "luqui"},
-	"luis":      {"lucho", "luisito", "luisi"},
-	"manuel":    {"manu", "manolo", "manuelito", "man"},
-	"marcelo":   {"marce", "marchelo", "marcelito"},
-	"marcos":    {"marki", "marquitos", "marc"},
-	"martin":    {"marti", "martincho", "tito", "martn"},
-	"mateo":     {"mate", "mateito", "teo"},
-	"matias":    {"mati", "maticho", "tias"},
-	"mauricio":  {"mauri", "mau", "mauricito"},
-	"maximiliano": {"maxi", "max", "maxito"},
-	"miguel":    {"miguelito", "migue", "mike", "mikel"},
-	"nicolas":   {"nico", "nikolas", "nicol", "nicolasito"},
-	"oscar":     {"osquitar", "osca", "ozzy"},
-	"pablo":     {"pabli", "pablito", "pabs"},
-	"patricio":  {"patri", "pato", "patrizio"},
-	"paulo":     {"pau", "paulito"},
-	"pedro":     {"pedrito", "pete", "pedrolo", "piero"},
-	"rafael":    {"rafa", "rafita", "rafo"},
-	"raul":      {"raulito", "rau", "ralito"},
-	"ricardo":   {"ricky", "rico", "ricar", "richardito"},
-	"roberto":   {"rober", "beto", "bob", "robertito"},
-	"rodrigo":   {"rodri", "rod", "rodrigo", "rodriguito"},
-	"romulo":    {"romi", "romu", "roms"},
-	"ruben":     {"rubi", "rubencito", "rube"},
-	"salvador":  {"salva", "chava", "salvadorito"},
-	"santiago":  {"santi", "sandy", "santito", "tiago"},
-	"sebastian": {"seba", "sebas", "sebi", "sebita"},
-	"sergio":    {"sergi", "sergy", "sergito"},
-	"tomas":     {"tomi", "tommy", "tomasito", "tom"},
-	"victor":    {"vict", "victo", "vic", "victorito"},
-	"walter":    {"wally", "walterito", "walt"},
-
-	// ---- Femeninos ----
-	"adriana":   {"adri", "adry", "adrianita"},
-	"agustina":  {"agus", "tina", "agustinita"},
-	"alejandra": {"ale", "alex", "aleja", "alejandrita"},
-	"andrea":    {"andy", "andre", "andreita"},
-	"analia":    {"ana", "anali", "analita"},
-	"angelica":  {"angel", "angie", "angeliquita"},
-	"barbara":   {"barbi", "barbie", "barbarita"},
-	"beatriz":   {"bea", "beti", "beatricita"},
-	"belen":     {"belu", "belit", "belencita"},
-	"brenda":    {"bren", "brendita"},
-	"camila":    {"cami", "camilita", "mila"},
-	"carla":     {"carlita", "carly", "car"},
-	"carolina":  {"caro", "carol", "carolinita", "lina"},
-	"catalina":  {"cata", "cati", "catita", "lina"},
-	"cecilia":   {"ceci", "cecilita", "cily"},
-	"celeste":   {"cele", "celestita"},
-	"claudia":   {"clau", "claudiita"},
-	"constanza": {"coni", "consti", "constanzita"},
-	"daniela":   {"dani", "danny", "danielita"},
-	"diana":     {"dianita", "diany"},
-	"elena":     {"ele", "elenita", "lena"},
-	"emilia":    {"emi", "emilita", "mili"},
-	"estefania": {"este", "stefi", "estefanita", "fany"},
-	"eugenia":   {"euge", "eugenita"},
-	"fernanda":  {"fer", "ferchu", "fernandita", "nanda"},
-	"florencia": {"flor", "florita", "florencita"},
-	"gabriela":  {"gabi", "gabo", "gabrielita"},
-	"gisela":    {"gise", "giselita"},
-	"graciela":  {"graci", "grace", "gracielita"},
-	"guadalupe": {"guada", "lupe", "lupita", "guadalupita"},
-	"jimena":    {"jime", "jimenita"},
-	"josefina":  {"jofi", "jose", "josefinita", "fina"},
-	"julieta":   {"juli", "julie", "julietita"},
-	"karina":    {"kari", "karinita"},
-	"laura":     {"lau", "laurita", "lauri"},
-	"leticia":   {"leti", "leticita"},
-	"lorena":    {"lore", "lorenita"},
-	"lucia":     {"lu", "luci", "lucita", "luciana"},
-	"luciana":   {"luci", "lu", "lucianita", "lucy"},
-	"luisa":     {"lui", "luisita"},
-	"magdalena": {"magda", "made", "magdalenita"},
-	"marcela":   {"marce", "marcelita"},
-	"maria":     {"mari", "mary", "maruja", "mariita"},
-	"mariana":   {"mari", "mary", "marianita", "ana"},
-	"marina":    {"mari", "marinita"},
-	"martina":   {"marti", "tinita", "martinita"},
-	"mercedes":  {"meche", "merce", "merche"},
-	"micaela":   {"mica", "micaelita", "micky"},
-	"monica":    {"moni", "monicaita"},
-	"natalia":   {"nati", "natalita", "nats"},
-	"noelia":    {"noe", "noelita"},
-	"paola":     {"pao", "paolita"},
-	"patricia":  {"patri", "pato", "patricita"},
-	"paula":     {"pau", "paulita"},
-	"pilar":     {"pili", "pilarcha", "pilarita"},
-	"romina":    {"romi", "rominita"},
-	"rosa":      {"rosita", "rosi"},
-	"sabrina":   {"sabri", "sabrinita"},
-	"silvana":   {"silvi", "silvarita"},
-	"silvia":    {"silvi", "silvita"},
-	"sofia":     {"sofi", "sofita"},
-	"soledad":   {"sole", "soledadita"},
-	"valeria":   {"vale", "vali", "valeriacita"},
-	"valentina": {"vale", "valen", "valentinita", "tina"},
-	"vanesa":    {"vane", "vanesita"},
-	"veronica":  {"vero", "veronicaita", "veri"},
-	"victoria":  {"vicky", "vic", "victorita", "viki"},
-	"viviana":   {"vivi", "vivianita"},
-	"yamila":    {"yami", "yamilita"},
-	"yesica":    {"yesi", "yesicita"},
+//go:embed data/nicknames/*.csv
+var nicknameFiles embed.FS
+
+// NicknameEntry es una línea del diccionario: un nombre con sus apodos.
+type NicknameEntry struct {
+	Name      string   // en minúsculas y sin tildes (ver nameKey)
+	Sexo      string   // H, M o vacío si el nombre es de ambos
+	Lang      string   // es, en, it, pt... o vacío si vale para todos
+	Nicknames []string // del más al menos usado
+}
+
+// nicknameDict: nombre → entradas de cada idioma. Las de los archivos
+// del usuario van primero.
+var nicknameDict = mustLoadNicknames()
+
+// defaultNicknameLangs son los idiomas de un pack sin NicknameLangs.
+var defaultNicknameLangs = []string{"es"}
+
+var nameAccents = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u",
+	"â", "a", "ê", "e", "ô", "o", "ã", "a", "õ", "o", "ç", "c",
+)
+
+// nameKey normaliza un nombre para buscarlo: minúsculas y sin tildes.
+func nameKey(name string) string {
+	return nameAccents.Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// mustLoadNicknames lee los diccionarios embebidos; un archivo mal
+// formado es un error de programación.
+func mustLoadNicknames() map[string][]NicknameEntry {
+	dict := make(map[string][]NicknameEntry)
+	files, err := fs.Glob(nicknameFiles, "data/nicknames/*.csv")
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range files {
+		f, err := nicknameFiles.Open(name)
+		if err != nil {
+			panic(err)
+		}
+		lang := strings.TrimSuffix(path.Base(name), ".csv")
+		entries, err := ParseNicknames(f, lang)
+		f.Close()
+		if err != nil {
+			panic(fmt.Sprintf("core: %s: %v", name, err))
+		}
+		for _, e := range entries {
+			dict[e.Name] = append(dict[e.Name], e)
+		}
+	}
+	return dict
+}
+
+// ParseNicknames lee un diccionario en formato nombre,sexo,apodos[,idioma]
+// (ver data/nicknames/es.csv). lang es el idioma de las líneas que no lo
+// indican. Las líneas vacías y las que empiezan con # se ignoran.
+func ParseNicknames(r io.Reader, lang string) ([]NicknameEntry, error) {
+	var entries []NicknameEntry
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		if len(fields) < 3 || len(fields) > 4 {
+			return nil, fmt.Errorf("diccionario de apodos, línea %d: se esperaba nombre,sexo,apodos[,idioma]", line)
+		}
+		e := NicknameEntry{
+			Name:      nameKey(fields[0]),
+			Sexo:      strings.ToUpper(strings.TrimSpace(fields[1])),
+			Lang:      lang,
+			Nicknames: strings.Fields(strings.ToLower(fields[2])),
+		}
+		if len(fields) == 4 {
+			e.Lang = strings.ToLower(strings.TrimSpace(fields[3]))
+		}
+		switch {
+		case e.Name == "":
+			return nil, fmt.Errorf("diccionario de apodos, línea %d: falta el nombre", line)
+		case e.Sexo != "" && e.Sexo != "H" && e.Sexo != "M":
+			return nil, fmt.Errorf("diccionario de apodos, línea %d: sexo %q (usar H, M o vacío)", line, fields[1])
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("diccionario de apodos: %w", err)
+	}
+	return entries, nil
+}
+
+// LoadNicknameFile suma al diccionario un archivo del usuario. Sus
+// entradas tienen prioridad: reemplazan a las del mismo nombre e idioma y
+// sus apodos van primero. Las líneas sin idioma valen para todos.
+// Se llama antes de generar, igual que utils.SetReferenceDate.
+func LoadNicknameFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("no se pudo leer el diccionario de apodos: %w", err)
+	}
+	defer f.Close()
+	entries, err := ParseNicknames(f, "")
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		kept := []NicknameEntry{e}
+		for _, old := range nicknameDict[e.Name] {
+			if old.Lang != e.Lang {
+				kept = append(kept, old)
+			}
+		}
+		nicknameDict[e.Name] = kept
+	}
+	return nil
+}
+
+// dictNicknames devuelve los apodos del diccionario para un nombre, en
+// el orden de langs y filtrando por sexo ("" = cualquiera). Si ninguna
+// entrada coincide con el sexo se usan todas: mejor un apodo de más que
+// perder "andrea" por estar cargado como nombre de mujer.
+func dictNicknames(name, sexo string, langs []string) []string {
+	var matches []NicknameEntry
+	for _, e := range nicknameDict[nameKey(name)] {
+		if e.Lang == "" || slices.Contains(langs, e.Lang) {
+			matches = append(matches, e)
+		}
+	}
+	// Primero las del usuario (sin idioma), después en el orden de langs
+	sort.SliceStable(matches, func(i, j int) bool {
+		return langRank(matches[i].Lang, langs) < langRank(matches[j].Lang, langs)
+	})
+
+	sexo = strings.ToUpper(sexo)
+	var nicks []string
+	for _, e := range matches {
+		if sexo == "" || e.Sexo == "" || e.Sexo == sexo {
+			nicks = append(nicks, e.Nicknames...)
+		}
+	}
+	if nicks == nil {
+		for _, e := range matches {
+			nicks = append(nicks, e.Nicknames...)
+		}
+	}
+	return nicks
+}
+
+// langRank es la posición de lang en langs; -1 para las entradas sin idioma.
+func langRank(lang string, langs []string) int {
+	if lang == "" {
+		return -1
+	}
+	return slices.Index(langs, lang)
 }
 
 // ================================
@@ -222,13 +243,24 @@ func generateRuleBasedNicknames(name string) []string {
 	return nicks
 }
 
-// GetNicknames devuelve todos los apodos posibles para un nombre dado.
-// Combina el diccionario curado + generación por reglas.
+// GetNicknames devuelve todos los apodos posibles para un nombre dado,
+// con el diccionario en español. Ver NicknamesFor.
 func GetNicknames(name string) []string {
+	return NicknamesFor(name, "", nil)
+}
+
+// NicknamesFor devuelve los apodos de un nombre: los del diccionario en
+// los idiomas del pack l (nil = español), preferentemente del sexo
+// indicado (H/M, "" = cualquiera), + generación por reglas.
+func NicknamesFor(name, sexo string, l *Locale) []string {
 	lower := strings.ToLower(strings.TrimSpace(name))
 	if lower == "" {
 		return nil
 	}
+	langs := defaultNicknameLangs
+	if l != nil && l.NicknameLangs != nil {
+		langs = l.NicknameLangs
+	}
 
 	seen := make(map[string]bool)
 	var result []string
@@ -242,10 +274,8 @@ func GetNicknames(name string) []string {
 	}
 
 	// 1. Buscar en diccionario curado
-	if nicks, ok := nicknameDict[lower]; ok {
-		for _, n := range nicks {
-			add(n)
-		}
+	for _, n := range dictNicknames(lower, sexo, langs) {
+		add(n)
 	}
 
 	// 2. Agregar siempre los generados por reglas
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"trickster/transforms"
 	"trickster/utils"
@@ -17,7 +18,7 @@ import (
 // latinoamericanos + CUPP "special relationships" module):
 //
 //   [NombreHijo][AñoNacimientoHijo]   → "valentina2015", "Valentina2015!"
-//   [NombreHijo][AñoActual]            → "valentina2024"
+//   [NombreHijo][AñoReciente]          → "valentina2024"
 //   [NombreMascota][Número]            → "firulais123", "Firulais1!"
 //   [NombreHijo]+[NombrePareja]        → "valentinajuan", "ValentinaJuan"
 //   [NombreObjetivo]+[NombreHijo]      → "carlosvalentina"
@@ -28,14 +29,14 @@ import (
 
 // Relative representa a una persona o mascota del entorno del objetivo
 type Relative struct {
-	Nombre    string
-	TipoVinc  string // "hijo", "pareja", "mascota", "padre", "madre", "hermano", etc.
-	AnioNac   string // año de nacimiento o adopción (opcional)
+	Nombre   string `json:"nombre" yaml:"nombre"`
+	TipoVinc string `json:"vinculo,omitempty" yaml:"vinculo,omitempty"` // "hijo", "pareja", "mascota", "padre", "madre", "hermano", etc.
+	AnioNac  string `json:"anio,omitempty" yaml:"anio,omitempty"`       // año de nacimiento o adopción (opcional)
 }
 
 // RelativesProfile agrupa todos los familiares/mascotas capturados por OSINT
 type RelativesProfile struct {
-	Parientes []Relative
+	Parientes []Relative `json:"parientes,omitempty" yaml:"parientes,omitempty"`
 }
 
 // AskRelatives guía al usuario para ingresar familiares y mascotas del objetivo.
@@ -57,7 +58,7 @@ func AskRelatives() RelativesProfile {
 			break
 		}
 		tipo := utils.AskOptional(fmt.Sprintf("  Vínculo (ej: hijo, mascota, pareja)"))
-		anio := utils.AskOptional(fmt.Sprintf("  Año nacimiento/adopción (opcional, ej: 2015)"))
+		anio := askValid("  Año nacimiento/adopción (opcional, ej: 2015)", ValidYear)
 
 		rp.Parientes = append(rp.Parientes, Relative{
 			Nombre:   strings.TrimSpace(nombre),
@@ -69,71 +70,76 @@ func AskRelatives() RelativesProfile {
 	return rp
 }
 
-// GenerateFromRelatives genera candidatos de contraseña a partir de
-// los familiares/mascotas del objetivo combinados con el perfil principal.
+// GenerateFromRelatives es la versión en memoria de StreamFromRelatives, sin duplicados.
 func GenerateFromRelatives(rp RelativesProfile, p Profile) []string {
-	seen := make(map[string]bool)
-	var result []string
-
-	add := func(s string) {
-		s = strings.TrimSpace(s)
-		l := len([]rune(s))
-		if l < 4 || l > 28 || seen[s] {
-			return
-		}
-		seen[s] = true
-		result = append(result, s)
-	}
+	return collect(func(emit Sink) { StreamFromRelatives(rp, p, 0, emit) })
+}
 
-	// Años relevantes para combinaciones con hijos/mascotas:
-	// Cubrimos 2005-2025 (años en que la mayoría tiene hijos o mascotas)
-	childYears := []string{
-		"2005", "2006", "2007", "2008", "2009",
-		"2010", "2011", "2012", "2013", "2014",
-		"2015", "2016", "2017", "2018", "2019",
-		"2020", "2021", "2022", "2023", "2024", "2025",
+// childYearWindow devuelve los años en que el objetivo pudo tener hijos o
+// mascotas, en 4 y 2 dígitos: desde sus 18 años (o 20 años atrás si no se
+// conoce su nacimiento) hasta el año de referencia, como mucho 30 años.
+func childYearWindow(p Profile) (years, short []string) {
+	to := utils.ReferenceYear()
+	from := to - 20
+	if y, err := strconv.Atoi(p.Anio); err == nil {
+		from = y + 18
 	}
-	childYearsShort := []string{
-		"05", "06", "07", "08", "09",
-		"10", "11", "12", "13", "14",
-		"15", "16", "17", "18", "19",
-		"20", "21", "22", "23", "24", "25",
+	from = max(from, to-30)
+	for y := from; y <= to; y++ {
+		years = append(years, strconv.Itoa(y))
+		short = append(short, fmt.Sprintf("%02d", y%100))
 	}
+	return years, short
+}
+
+// StreamFromRelatives genera candidatos de contraseña a partir de
+// los familiares/mascotas del objetivo combinados con el perfil principal.
+// Cada familiar se procesa en paralelo (workers, 0 = uno por CPU) y la
+// salida conserva el orden en que fueron cargados.
+func StreamFromRelatives(rp RelativesProfile, p Profile, workers int, emit Sink) {
+	// Años relevantes para combinaciones con hijos/mascotas (ver childYearWindow)
+	childYears, childYearsShort := childYearWindow(p)
 
+	locale, _ := LookupLocale(p.Locale)
 	nombreObjetivo := strings.ToLower(strings.TrimSpace(p.Nombre))
 	apellidoObjetivo := strings.ToLower(strings.TrimSpace(p.Apellido))
 
-	for _, rel := range rp.Parientes {
+	// Cada familiar es una tarea independiente (ver parallel.go)
+	runOrdered(workers, len(rp.Parientes), func(i int, add Sink) {
+		rel := rp.Parientes[i]
 		rn := strings.ToLower(strings.TrimSpace(rel.Nombre))
 		if rn == "" {
-			continue
+			return
 		}
 		rnc := transforms.Capitalize(rn)
 		rnu := transforms.ToUpper(rn)
 		rnLeet := leetSimple(rn)
+		rw := relativeWeight(rel.TipoVinc)
 
 		// ── Formas base del familiar ─────────────────────────
-		add(rn)
-		add(rnc)
-		add(rnu)
-		add(rnLeet)
-		add(transforms.Reverse(rn))
+		add(rn, rw*wLower)
+		add(rnc, rw*wCap)
+		add(rnu, rw*wUpper)
+		add(rnLeet, rw*wLeet)
+		add(transforms.Reverse(rn), rw*wReverse)
 
 		// ── Familiar + sufijos numéricos comunes ─────────────
 		// (patrones más frecuentes en contraseñas con nombres propios)
-		for _, num := range []string{
+		for k, num := range []string{
 			"1", "2", "3", "12", "21", "123", "1234", "12345",
 			"0", "00", "01", "007",
 			"111", "222", "333", "777", "999",
 		} {
-			add(rn + num)
-			add(rnc + num)
+			w := rw * 0.9 * freq(k)
+			add(rn+num, w*wLower)
+			add(rnc+num, w*wCap)
 		}
 
 		// ── Familiar + sufijos de símbolo ─────────────────────
-		for _, sp := range []string{"!", "!!", ".", "@", "#", "1!", "123!", "!1"} {
-			add(rn + sp)
-			add(rnc + sp)
+		for k, sp := range []string{"!", "!!", ".", "@", "#", "1!", "123!", "!1"} {
+			w := rw * 0.7 * freq(k)
+			add(rn+sp, w*wLower)
+			add(rnc+sp, w*wCap)
 		}
 
 		// ── Familiar + año conocido (si se ingresó) ───────────
@@ -144,51 +150,55 @@ func GenerateFromRelatives(rp RelativesProfile, p Profile) []string {
 				ayShort = ay[2:]
 			}
 
-			add(rn + ay)
-			add(rnc + ay)
-			add(rnu + ay)
-			add(rnLeet + ay)
-			add(ay + rn)
-			add(ay + rnc)
+			add(rn+ay, rw*wLower)
+			add(rnc+ay, rw*wCap)
+			add(rnu+ay, rw*wUpper)
+			add(rnLeet+ay, rw*wLeet)
+			add(ay+rn, rw*0.45*wLower)
+			add(ay+rnc, rw*0.45*wCap)
 
 			if ayShort != "" {
-				add(rn + ayShort)
-				add(rnc + ayShort)
-				add(ayShort + rn)
+				add(rn+ayShort, rw*0.9*wLower)
+				add(rnc+ayShort, rw*0.9*wCap)
+				add(ayShort+rn, rw*0.4)
 			}
 
-			for _, sp := range []string{"!", "@", "#", ".", "1", "123"} {
-				add(rn + ay + sp)
-				add(rnc + ay + sp)
+			for k, sp := range []string{"!", "@", "#", ".", "1", "123"} {
+				w := rw * 0.8 * freq(k)
+				add(rn+ay+sp, w*wLower)
+				add(rnc+ay+sp, w*wCap)
 				if ayShort != "" {
-					add(rn + ayShort + sp)
-					add(rnc + ayShort + sp)
+					add(rn+ayShort+sp, w*0.8*wLower)
+					add(rnc+ayShort+sp, w*0.8*wCap)
 				}
 			}
 
 			// Sándwich: año-familiar-año
-			add(ay + rn + ay)
+			add(ay+rn+ay, rw*0.2)
 			if ayShort != "" {
-				add(ayShort + rn + ayShort)
+				add(ayShort+rn+ayShort, rw*0.2)
 			}
 		}
 
 		// ── Familiar × años relevantes (aunque no se conozca el año) ─
-		// Para hijos/mascotas cubrimos 2005-2025
+		// Para hijos/mascotas cubrimos la ventana de childYearWindow; sin año
+		// conocido cada uno es apenas una de ~20 posibilidades, de ahí el
+		// peso bajo.
 		isMascotaOHijo := strings.Contains(rel.TipoVinc, "hijo") ||
 			strings.Contains(rel.TipoVinc, "mascota") ||
 			strings.Contains(rel.TipoVinc, "hija") ||
 			rel.TipoVinc == ""
 
 		if isMascotaOHijo {
+			w := rw * 0.25
 			for _, y := range childYears {
-				add(rn + y)
-				add(rnc + y)
-				add(y + rn)
+				add(rn+y, w*wLower)
+				add(rnc+y, w*wCap)
+				add(y+rn, w*0.4)
 			}
 			for _, y := range childYearsShort {
-				add(rn + y)
-				add(rnc + y)
+				add(rn+y, w*0.8*wLower)
+				add(rnc+y, w*0.8*wCap)
 			}
 		}
 
@@ -196,31 +206,33 @@ func GenerateFromRelatives(rp RelativesProfile, p Profile) []string {
 		if nombreObjetivo != "" {
 			no := nombreObjetivo
 			noc := transforms.Capitalize(no)
+			w := rw * weightNombre * 0.5
 
-			add(no + rn)
-			add(rn + no)
-			add(noc + rnc)
-			add(rnc + noc)
-			add(no + "_" + rn)
-			add(rn + "_" + no)
-			add(no + "." + rn)
+			add(no+rn, w*wLower)
+			add(rn+no, w*wLower)
+			add(noc+rnc, w*wCap)
+			add(rnc+noc, w*wCap)
+			add(no+"_"+rn, w*0.4)
+			add(rn+"_"+no, w*0.4)
+			add(no+"."+rn, w*0.3)
 
 			if p.Anio != "" {
-				add(no + rn + p.Anio)
-				add(rn + no + p.Anio)
-				add(noc + rnc + p.Anio)
+				add(no+rn+p.Anio, w*0.7)
+				add(rn+no+p.Anio, w*0.7)
+				add(noc+rnc+p.Anio, w*0.7*wCap)
 			}
 		}
 
 		if apellidoObjetivo != "" {
 			ao := apellidoObjetivo
 			aoc := transforms.Capitalize(ao)
-			add(rn + ao)
-			add(rnc + aoc)
-			add(ao + rn)
+			w := rw * weightApellido * 0.5
+			add(rn+ao, w*wLower)
+			add(rnc+aoc, w*wCap)
+			add(ao+rn, w*0.6)
 			if p.Anio != "" {
-				add(rn + ao + p.Anio)
-				add(rnc + aoc + p.Anio)
+				add(rn+ao+p.Anio, w*0.7*wLower)
+				add(rnc+aoc+p.Anio, w*0.7*wCap)
 			}
 		}
 
@@ -231,57 +243,57 @@ func GenerateFromRelatives(rp RelativesProfile, p Profile) []string {
 				continue
 			}
 			rn2c := transforms.Capitalize(rn2)
+			w := rw * relativeWeight(rel2.TipoVinc) * 0.5
 
-			add(rn + rn2)
-			add(rnc + rn2c)
-			add(rn + "_" + rn2)
+			add(rn+rn2, w*wLower)
+			add(rnc+rn2c, w*wCap)
+			add(rn+"_"+rn2, w*0.4)
 
 			if p.Anio != "" {
-				add(rn + rn2 + p.Anio)
-				add(rnc + rn2c + p.Anio)
+				add(rn+rn2+p.Anio, w*0.7*wLower)
+				add(rnc+rn2c+p.Anio, w*0.7*wCap)
 			}
 		}
 
 		// ── Apodos del familiar ───────────────────────────────
-		nicks := GetNicknames(rel.Nombre)
-		for _, nick := range nicks {
+		nicks := NicknamesFor(rel.Nombre, "", locale)
+		for j, nick := range nicks {
 			nc := transforms.Capitalize(nick)
-			add(nick)
-			add(nc)
+			nw := rw * weightNickname * freq(j)
+			add(nick, nw*wLower)
+			add(nc, nw*wCap)
 
-			for _, num := range []string{"1", "12", "123", "0", "00"} {
-				add(nick + num)
-				add(nc + num)
+			for k, num := range []string{"1", "12", "123", "0", "00"} {
+				add(nick+num, nw*0.9*freq(k)*wLower)
+				add(nc+num, nw*0.9*freq(k)*wCap)
 			}
-			for _, sp := range []string{"!", "!!", "@", "."} {
-				add(nick + sp)
-				add(nc + sp)
+			for k, sp := range []string{"!", "!!", "@", "."} {
+				add(nick+sp, nw*0.7*freq(k)*wLower)
+				add(nc+sp, nw*0.7*freq(k)*wCap)
 			}
 
 			if rel.AnioNac != "" {
-				add(nick + rel.AnioNac)
-				add(nc + rel.AnioNac)
+				add(nick+rel.AnioNac, nw*wLower)
+				add(nc+rel.AnioNac, nw*wCap)
 			}
 			if isMascotaOHijo {
 				for _, y := range childYears {
-					add(nick + y)
-					add(nc + y)
+					add(nick+y, nw*0.25*wLower)
+					add(nc+y, nw*0.25*wCap)
 				}
 			}
 			if nombreObjetivo != "" {
-				add(nombreObjetivo + nick)
-				add(nick + nombreObjetivo)
+				add(nombreObjetivo+nick, nw*weightNombre*0.5)
+				add(nick+nombreObjetivo, nw*weightNombre*0.5)
 			}
 		}
 
 		// ── Leet de todos los años del familiar ───────────────
 		if rel.AnioNac != "" {
 			for _, lv := range leetAllVariants(rn) {
-				add(lv + rel.AnioNac)
-				add(transforms.Capitalize(lv) + rel.AnioNac)
+				add(lv+rel.AnioNac, rw*wLeetAll)
+				add(transforms.Capitalize(lv)+rel.AnioNac, rw*wLeetAll*wCap)
 			}
 		}
-	}
-
-	return result
+	}, emit)
 }
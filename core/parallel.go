@@ -0,0 +1,62 @@
+package core
+
+import "runtime"
+
+// ================================================================
+// GENERACIÓN PARALELA CON ORDEN DETERMINÍSTICO
+//
+// Los pasos pesados (cada átomo, cada familiar, cada bloque de DNI)
+// se reparten en tareas independientes. Cada tarea escribe en su
+// propio buffer y los buffers se vuelcan en el orden de las tareas,
+// no en el orden en que terminan: la salida es idéntica a la de una
+// ejecución secuencial, sin importar la cantidad de workers.
+// ================================================================
+
+// resolveWorkers normaliza la cantidad de workers (0 = un worker por CPU).
+func resolveWorkers(workers int) int {
+	if workers <= 0 {
+		return runtime.NumCPU()
+	}
+	return workers
+}
+
+// runOrdered ejecuta task(0..n-1) en paralelo y envía su salida a out
+// en orden de índice. Como mucho hay `workers` tareas en vuelo sin
+// volcar, así que la memoria queda acotada aunque una tarea sea lenta.
+// out siempre se llama desde la goroutine que invoca a runOrdered.
+func runOrdered(workers, n int, task func(i int, emit Sink), out Sink) {
+	workers = resolveWorkers(workers)
+	if workers == 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			task(i, out)
+		}
+		return
+	}
+
+	results := make([]chan []Candidate, n)
+	for i := range results {
+		results[i] = make(chan []Candidate, 1)
+	}
+
+	// Cada slot del semáforo es una tarea lanzada y todavía no volcada
+	slots := make(chan struct{}, workers)
+	go func() {
+		for i := 0; i < n; i++ {
+			slots <- struct{}{}
+			go func(i int) {
+				var buf []Candidate
+				task(i, func(s string, score float64) {
+					buf = append(buf, Candidate{s, score})
+				})
+				results[i] <- buf
+			}(i)
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		for _, c := range <-results[i] {
+			out(c.Value, c.Score)
+		}
+		<-slots
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"trickster/output"
+)
+
+// ================================================================
+// PIPELINE DE GENERACIÓN EN STREAMING
+//
+// Los generadores no arman slices: envían cada candidato a un Sink
+// apenas lo construyen. El flujo del perfil avanzado queda así:
+//
+//   generadores ──► CandidateSet ──► canal ──► output.WriteWordlistStream
+//
+// Así la memoria no crece con las combinaciones profundas ni con el
+// rango de DNI: lo único que se conserva es el set de ya vistos.
+// La excepción es el ranking por probabilidad (rank.go), que necesita
+// ver todos los candidatos antes de escribir el primero.
+// ================================================================
+
+// Sink recibe cada candidato apenas se genera, junto con su puntaje
+// de probabilidad (ver rank.go).
+type Sink func(s string, score float64)
+
+// collect ejecuta un productor y junta su salida deduplicada en un slice.
+// Mantiene las funciones Generate* que devuelven []string.
+func collect(produce func(emit Sink)) []string {
+	var result []string
+	set := NewCandidateSet(func(s string, _ float64) {
+		result = append(result, s)
+	})
+	produce(set.Add)
+	set.Close() // el set exacto no acumula ni falla
+	return result
+}
+
+// streamWordlist ejecuta produce en una goroutine y escribe cada candidato
+// en outputPath a medida que llega. Devuelve cuántas palabras se escribieron.
+// Los errores de escritura se distinguen de los de generación, que se
+// devuelven tal cual.
+func streamWordlist(outputPath string, produce func(emit Sink) error) (int, error) {
+	words := make(chan string, 4096)
+	done := make(chan struct{})
+	count := 0
+	var genErr error
+
+	go func() {
+		defer close(words)
+		genErr = produce(func(s string, _ float64) {
+			select {
+			case words <- s:
+				count++
+			case <-done:
+				// El escritor falló: se descarta el resto sin bloquear
+			}
+		})
+	}()
+
+	if err := output.WriteWordlistStream(words, outputPath); err != nil {
+		close(done)
+		for range words {
+			// Esperar a que el productor termine antes de volver
+		}
+		return 0, fmt.Errorf("no se pudo guardar %s: %w", outputPath, err)
+	}
+	return count, genErr
+}
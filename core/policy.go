@@ -0,0 +1,260 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"trickster/utils"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ================================================================
+// POLÍTICA DE CONTRASEÑAS
+//
+// Describe las reglas reales del sistema objetivo para no probar
+// candidatos imposibles. Se aplica en todos los modos: en el perfil
+// avanzado la aplica el CandidateSet y en máscaras y variantes se
+// filtra la lista final.
+//
+// Los campos en cero no restringen nada. DefaultPolicy conserva la
+// regla histórica del perfil avanzado: entre 4 y 28 runas.
+// ================================================================
+
+// Clases de caracteres que una política puede exigir.
+const (
+	ClassLower  = "lower"
+	ClassUpper  = "upper"
+	ClassDigit  = "digit"
+	ClassSymbol = "symbol"
+)
+
+// PolicyClasses lista las clases en el orden en que se ofrecen al usuario.
+var PolicyClasses = []string{ClassLower, ClassUpper, ClassDigit, ClassSymbol}
+
+// Policy es el conjunto de reglas que debe cumplir cada candidato.
+type Policy struct {
+	MinLen     int      // mínimo de runas (0 = sin mínimo)
+	MaxLen     int      // máximo de runas (0 = sin máximo)
+	MaxBytes   int      // máximo de bytes UTF-8, ej: 72 en bcrypt (0 = sin máximo)
+	Require    []string // clases obligatorias: lower, upper, digit, symbol
+	MinClasses int      // cantidad mínima de clases distintas presentes (ej: 3 de 4 en AD)
+	Charset    string   // caracteres permitidos, con sintaxis de clase de regexp (ej: a-zA-Z0-9!@#)
+	Pattern    string   // regexp que el candidato debe cumplir
+	MaxRepeat  int      // máximo de caracteres iguales seguidos (0 = sin límite)
+
+	charset *regexp.Regexp
+	pattern *regexp.Regexp
+	require [4]bool // índices según PolicyClasses
+}
+
+// DefaultPolicy es la regla de longitud que el perfil avanzado aplicó siempre.
+func DefaultPolicy() *Policy {
+	return &Policy{MinLen: 4, MaxLen: 28}
+}
+
+// Compile valida la política y prepara las expresiones regulares.
+// Debe llamarse antes de Allows. Una política nil es válida.
+func (p *Policy) Compile() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxLen > 0 && p.MinLen > p.MaxLen {
+		return fmt.Errorf("política inválida: longitud mínima %d mayor que la máxima %d", p.MinLen, p.MaxLen)
+	}
+	if p.MinClasses > len(PolicyClasses) {
+		return fmt.Errorf("política inválida: no hay %d clases de caracteres (máximo %d)", p.MinClasses, len(PolicyClasses))
+	}
+
+	p.require = [4]bool{}
+	for _, class := range p.Require {
+		i := classIndex(class)
+		if i < 0 {
+			return fmt.Errorf("clase de caracteres desconocida %q (usar %s)", class, strings.Join(PolicyClasses, ", "))
+		}
+		p.require[i] = true
+	}
+
+	p.charset, p.pattern = nil, nil
+	if p.Charset != "" {
+		re, err := regexp.Compile("^[" + p.Charset + "]*$")
+		if err != nil {
+			return fmt.Errorf("charset inválido %q: %w", p.Charset, err)
+		}
+		p.charset = re
+	}
+	if p.Pattern != "" {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return fmt.Errorf("regex inválida %q: %w", p.Pattern, err)
+		}
+		p.pattern = re
+	}
+	return nil
+}
+
+// Allows indica si s cumple todas las reglas. Una política nil acepta todo.
+func (p *Policy) Allows(s string) bool {
+	if p == nil {
+		return true
+	}
+	n := utf8.RuneCountInString(s)
+	if n < p.MinLen || (p.MaxLen > 0 && n > p.MaxLen) {
+		return false
+	}
+	if p.MaxBytes > 0 && len(s) > p.MaxBytes {
+		return false
+	}
+
+	var has [4]bool
+	run, prev := 0, rune(-1)
+	for _, r := range s {
+		has[runeClass(r)] = true
+		if r == prev {
+			run++
+		} else {
+			run, prev = 1, r
+		}
+		if p.MaxRepeat > 0 && run > p.MaxRepeat {
+			return false
+		}
+	}
+	classes := 0
+	for i, req := range p.require {
+		if req && !has[i] {
+			return false
+		}
+		if has[i] {
+			classes++
+		}
+	}
+	if classes < p.MinClasses {
+		return false
+	}
+
+	if p.charset != nil && !p.charset.MatchString(s) {
+		return false
+	}
+	if p.pattern != nil && !p.pattern.MatchString(s) {
+		return false
+	}
+	return true
+}
+
+// Filter devuelve las palabras de words que cumplen la política.
+// Con p nil devuelve words sin tocar.
+func (p *Policy) Filter(words []string) []string {
+	if p == nil {
+		return words
+	}
+	result := make([]string, 0, len(words))
+	for _, w := range words {
+		if p.Allows(w) {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// classIndex devuelve la posición de class en PolicyClasses, o -1.
+func classIndex(class string) int {
+	class = strings.ToLower(strings.TrimSpace(class))
+	for i, c := range PolicyClasses {
+		if c == class {
+			return i
+		}
+	}
+	return -1
+}
+
+// runeClass clasifica r como minúscula, mayúscula, dígito o símbolo.
+func runeClass(r rune) int {
+	switch {
+	case unicode.IsLower(r):
+		return 0
+	case unicode.IsUpper(r):
+		return 1
+	case unicode.IsDigit(r):
+		return 2
+	default:
+		return 3
+	}
+}
+
+// askPolicy pregunta qué política aplicar: un preset (presets.go), una
+// política a medida o ninguna, en cuyo caso devuelve base (que puede ser nil).
+func askPolicy(base *Policy) *Policy {
+	fmt.Println()
+	utils.Info("Políticas de contraseñas disponibles:")
+	for _, preset := range PolicyPresets {
+		fmt.Printf("    %-15s %s\n", preset.Name, preset.Description)
+	}
+	fmt.Printf("    %-15s %s\n", "custom", "definir las reglas a mano")
+
+	for {
+		answer := strings.ToLower(utils.AskOptional("Política del sistema objetivo (Enter = ninguna)"))
+		switch answer {
+		case "":
+			return base
+		case "custom":
+			return askCustomPolicy(base)
+		}
+		p, err := PresetPolicy(answer)
+		if err == nil {
+			return p
+		}
+		utils.Error(err.Error())
+	}
+}
+
+// askCustomPolicy pregunta las reglas una a una, partiendo de base.
+func askCustomPolicy(base *Policy) *Policy {
+	for {
+		p := &Policy{}
+		if base != nil {
+			*p = *base
+		}
+		p.MinLen = askInt("Longitud mínima", p.MinLen)
+		p.MaxLen = askInt("Longitud máxima (0 = sin máximo)", p.MaxLen)
+		p.MaxBytes = askInt("Máximo de bytes UTF-8 (0 = sin máximo)", p.MaxBytes)
+		require := askText("Clases obligatorias ("+strings.Join(PolicyClasses, ",")+")", strings.Join(p.Require, ","))
+		p.Require = utils.SplitList(require)
+		p.MinClasses = askInt("Mínimo de clases distintas (0 = sin mínimo)", p.MinClasses)
+		p.Charset = askText("Caracteres permitidos (ej: a-zA-Z0-9!@#)", p.Charset)
+		p.Pattern = askText("Regex que debe cumplir", p.Pattern)
+		p.MaxRepeat = askInt("Máximo de caracteres iguales seguidos (0 = sin límite)", p.MaxRepeat)
+
+		if err := p.Compile(); err != nil {
+			utils.Error(err.Error())
+			continue
+		}
+		return p
+	}
+}
+
+// askText pide un texto; Enter deja def.
+func askText(question, def string) string {
+	if def != "" {
+		question = fmt.Sprintf("%s [%s]", question, def)
+	}
+	if raw := utils.AskOptional(question); raw != "" {
+		return raw
+	}
+	return def
+}
+
+// askInt pide un entero no negativo; Enter deja def.
+func askInt(question string, def int) int {
+	for {
+		raw := utils.AskOptional(fmt.Sprintf("%s [%d]", question, def))
+		if raw == "" {
+			return def
+		}
+		n, err := strconv.Atoi(raw)
+		if err == nil && n >= 0 {
+			return n
+		}
+		utils.Error(fmt.Sprintf("Número inválido %q.", raw))
+	}
+}
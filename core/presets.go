@@ -0,0 +1,187 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"trickster/transforms"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ================================================================
+// PRESETS DE POLÍTICA
+//
+// Reglas de sistemas habituales en auditorías, listas para elegir
+// desde el menú o con -policy en la CLI. Los flags de política que se
+// pasen explícitamente se aplican encima del preset.
+//
+// Cuando la política exige mayúscula + número + símbolo (o 3 de 4
+// clases, como AD) el perfil avanzado enfatiza las formas que la
+// cumplen, Capitalizada + número + símbolo, de dos maneras:
+//
+//   - StreamComplexForms las genera antes que el resto del módulo
+//     profile, así que encabezan la salida aun sin ranking
+//   - emphasizeComplex duplica su puntaje, lo que solo cambia algo con
+//     -rank o con un presupuesto (-limit, -module-limit)
+// ================================================================
+
+// PolicyPresetInfo describe un preset de política.
+type PolicyPresetInfo struct {
+	Name        string
+	Description string
+	Policy      Policy
+}
+
+// PolicyPresets lista los presets en el orden en que se ofrecen al usuario.
+var PolicyPresets = []PolicyPresetInfo{
+	{
+		Name:        "ad",
+		Description: "Active Directory, complejidad por defecto: 7+ caracteres, 3 de 4 clases",
+		Policy:      Policy{MinLen: 7, MinClasses: 3},
+	},
+	{
+		Name:        "complex",
+		Description: "complejidad estricta: 8+ caracteres con minúscula, mayúscula, número y símbolo",
+		Policy:      Policy{MinLen: 8, Require: []string{ClassLower, ClassUpper, ClassDigit, ClassSymbol}},
+	},
+	{
+		Name:        "wpa2",
+		Description: "clave WPA2-PSK: 8 a 63 caracteres ASCII imprimibles",
+		Policy:      Policy{MinLen: 8, MaxLen: 63, Charset: `\x20-\x7e`},
+	},
+	{
+		Name:        "pin4",
+		Description: "PIN numérico de 4 dígitos",
+		Policy:      Policy{MinLen: 4, MaxLen: 4, Charset: "0-9"},
+	},
+	{
+		Name:        "pin6",
+		Description: "PIN numérico de 6 dígitos",
+		Policy:      Policy{MinLen: 6, MaxLen: 6, Charset: "0-9"},
+	},
+	{
+		Name:        "homebanking-ar",
+		Description: "home banking argentino típico: 8 a 15 alfanuméricos, letra y número, sin 3 iguales seguidos",
+		Policy: Policy{
+			MinLen: 8, MaxLen: 15, Charset: "a-zA-Z0-9",
+			Require: []string{ClassDigit}, Pattern: "[a-zA-Z]", MaxRepeat: 2,
+		},
+	},
+	{
+		Name:        "bcrypt",
+		Description: "hashes bcrypt: solo cuentan los primeros 72 bytes",
+		Policy:      Policy{MinLen: 1, MaxBytes: 72},
+	},
+}
+
+// PresetPolicy devuelve una copia compilada del preset indicado.
+func PresetPolicy(name string) (*Policy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, preset := range PolicyPresets {
+		if preset.Name == name {
+			p := preset.Policy
+			p.Require = append([]string(nil), p.Require...)
+			if err := p.Compile(); err != nil {
+				return nil, err
+			}
+			return &p, nil
+		}
+	}
+
+	names := make([]string, len(PolicyPresets))
+	for i, preset := range PolicyPresets {
+		names[i] = preset.Name
+	}
+	return nil, fmt.Errorf("preset de política desconocido %q (usar %s)", name, strings.Join(names, ", "))
+}
+
+// Complex indica si la política obliga a combinar mayúscula, número y
+// símbolo, ya sea explícitamente o pidiendo 3 o más clases distintas.
+func (p *Policy) Complex() bool {
+	if p == nil {
+		return false
+	}
+	if p.MinClasses >= 3 {
+		return true
+	}
+	return p.require[1] && p.require[2] && p.require[3]
+}
+
+// complexBoost multiplica el puntaje de las formas que cumplen una
+// política compleja, para que el ranking y los presupuestos las prefieran.
+const complexBoost = 2.0
+
+// emphasizeComplex envuelve emit y multiplica por complexBoost el puntaje
+// de los candidatos Capitalizados que terminan en número + símbolo. Sin
+// ranking ni presupuesto el puntaje se ignora y no cambia la salida.
+func emphasizeComplex(emit Sink) Sink {
+	return func(s string, score float64) {
+		if looksComplex(s) {
+			score *= complexBoost
+		}
+		emit(s, score)
+	}
+}
+
+// looksComplex reconoce la forma Carlos1990! / Boca12#: mayúscula inicial,
+// y al final uno o más dígitos seguidos de uno o más símbolos.
+func looksComplex(s string) bool {
+	first, _ := utf8.DecodeRuneInString(s)
+	if !unicode.IsUpper(first) {
+		return false
+	}
+	rest := strings.TrimRightFunc(s, func(r rune) bool { return runeClass(r) == 3 })
+	if rest == s {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(rest)
+	return unicode.IsDigit(last)
+}
+
+// StreamComplexForms genera, para cada átomo de texto del perfil, las
+// formas que exige una política de complejidad: Capitalizada + cada sufijo
+// de numSymbolSuffixes (Carlos1!, Boca123#). Después, con menos peso,
+// Capitalizada + sufijo numérico + símbolo (Carlos07!, Boca2015#), que la
+// generación base solo cubre para el año de nacimiento. Los átomos
+// derivados de la fecha de nacimiento (signo, década...) no participan.
+func StreamComplexForms(p Profile, emit Sink) {
+	const topNums, topSyms = 40, 8
+
+	atoms := buildAtoms(p)
+	for _, a := range atoms {
+		if a.isNumber || a.derived {
+			continue
+		}
+		c := transforms.Capitalize(a.val)
+		for k, ns := range numSymbolSuffixes {
+			emit(c+ns, a.weight*0.6*freq(k)*wCap)
+		}
+	}
+
+	// ── Respaldo: número × símbolo ────────────────────────────────
+	nums := numSuffixes()
+	if len(nums) > topNums {
+		nums = nums[:topNums]
+	}
+	var syms []string
+	for _, sp := range specialSuffixes {
+		if len(syms) == topSyms {
+			break
+		}
+		onlySymbols := strings.IndexFunc(sp, func(r rune) bool { return runeClass(r) != 3 }) < 0
+		if onlySymbols {
+			syms = append(syms, sp)
+		}
+	}
+	for _, a := range atoms {
+		if a.isNumber || a.derived {
+			continue
+		}
+		c := transforms.Capitalize(a.val)
+		for i, num := range nums {
+			for j, sp := range syms {
+				emit(c+num+sp, a.weight*0.4*freq(i)*freq(j)*wCap)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ================================================================
+// ARCHIVOS DE PERFIL (JSON / YAML)
+//
+// Permite guardar las respuestas de RunProfiler y volver a cargarlas
+// más tarde, para versionar los objetivos junto a las notas del
+// engagement y regenerar la wordlist con los mismos datos.
+//
+// El formato se elige por la extensión (.json, .yaml, .yml). Ejemplo:
+//
+//   nombre: carlos
+//   apellido: perez
+//   fecha_nacimiento: "15031990"
+//   equipo_futbol: boca
+//   fechas:
+//     - etiqueta: aniversario
+//       fecha: "20062015"
+//   familiares:
+//     - nombre: luna
+//       vinculo: mascota
+//       anio: "2019"
+// ================================================================
+
+// ProfileFile es la representación en disco de un objetivo:
+// los campos del perfil más la lista de familiares/mascotas.
+type ProfileFile struct {
+	Profile    `yaml:",inline"`
+	Familiares []Relative `json:"familiares,omitempty" yaml:"familiares,omitempty"`
+}
+
+// LoadProfileFile lee un perfil guardado y deriva día/mes/año de la fecha.
+func LoadProfileFile(path string) (Profile, RelativesProfile, error) {
+	var pf ProfileFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, RelativesProfile{}, fmt.Errorf("no se pudo leer el perfil: %w", err)
+	}
+
+	format, err := profileFormat(path)
+	if err != nil {
+		return Profile{}, RelativesProfile{}, err
+	}
+
+	if format == "json" {
+		err = json.Unmarshal(data, &pf)
+	} else {
+		err = yaml.Unmarshal(data, &pf)
+	}
+	if err != nil {
+		return Profile{}, RelativesProfile{}, fmt.Errorf("perfil inválido en %s: %w", path, err)
+	}
+
+	p := pf.Profile
+	if _, err := p.SetFechaNacimiento(p.FechaNacimiento); err != nil {
+		return Profile{}, RelativesProfile{}, fmt.Errorf("perfil inválido en %s: %w", path, err)
+	}
+	return p, RelativesProfile{Parientes: pf.Familiares}, nil
+}
+
+// SaveProfileFile guarda el perfil y sus familiares en JSON o YAML según la extensión.
+func SaveProfileFile(path string, p Profile, rp RelativesProfile) error {
+	format, err := profileFormat(path)
+	if err != nil {
+		return err
+	}
+
+	pf := ProfileFile{Profile: p, Familiares: rp.Parientes}
+
+	var data []byte
+	if format == "json" {
+		data, err = json.MarshalIndent(pf, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(pf)
+	}
+	if err != nil {
+		return fmt.Errorf("no se pudo serializar el perfil: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("no se pudo guardar el perfil: %w", err)
+	}
+	return nil
+}
+
+// profileFormat deduce el formato ("json" o "yaml") a partir de la extensión.
+func profileFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	default:
+		return "", fmt.Errorf("extensión de perfil no soportada %q (usar .json, .yaml o .yml)", filepath.Ext(path))
+	}
+}
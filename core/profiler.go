@@ -2,30 +2,47 @@ package core
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"trickster/output"
 	"trickster/transforms"
 	"trickster/utils"
 )
 
-// Profile contiene todos los datos personales del objetivo
+// Profile contiene todos los datos personales del objetivo.
+// Los tags definen el formato de archivo de perfil (ver profile-file.go);
+// Dia, Mes, Anio y AnioCorto se derivan de FechaNacimiento al cargar (ver
+// SetFechaNacimiento); Dia y Mes quedan vacíos si la fecha es parcial.
 type Profile struct {
-	Nombre          string
-	Apellido        string
-	DNI             string
-	FechaNacimiento string
-	Dia             string
-	Mes             string
-	Anio            string
-	AnioCorto       string
-	EquipoFutbol    string
-	Edad            string
-	Ciudad          string
-	Mascota         string
-	Pareja          string
-	OldPass1        string
-	OldPass2        string
-	OldPass3        string
+	Nombre           string `json:"nombre,omitempty" yaml:"nombre,omitempty"`
+	Apellido         string `json:"apellido,omitempty" yaml:"apellido,omitempty"`
+	SegundoApellido  string `json:"segundo_apellido,omitempty" yaml:"segundo_apellido,omitempty"`   // apellido materno (CURP/RFC)
+	Sexo             string `json:"sexo,omitempty" yaml:"sexo,omitempty"`                           // H / M, como en la CURP
+	EstadoNacimiento string `json:"estado_nacimiento,omitempty" yaml:"estado_nacimiento,omitempty"` // entidad de nacimiento, código (DF) o nombre
+	DNI              string `json:"dni,omitempty" yaml:"dni,omitempty"`
+	FechaNacimiento  string `json:"fecha_nacimiento,omitempty" yaml:"fecha_nacimiento,omitempty"`
+	Dia              string `json:"-" yaml:"-"`
+	Mes              string `json:"-" yaml:"-"`
+	Anio             string `json:"-" yaml:"-"`
+	AnioCorto        string `json:"-" yaml:"-"`
+	EquipoFutbol     string `json:"equipo_futbol,omitempty" yaml:"equipo_futbol,omitempty"`
+	Edad             string `json:"edad,omitempty" yaml:"edad,omitempty"`
+	Ciudad           string `json:"ciudad,omitempty" yaml:"ciudad,omitempty"`
+	Celular          string `json:"celular,omitempty" yaml:"celular,omitempty"`
+	Telefono         string `json:"telefono,omitempty" yaml:"telefono,omitempty"` // fijo
+	Marca            string `json:"marca,omitempty" yaml:"marca,omitempty"`       // marca del vehículo
+	Modelo           string `json:"modelo,omitempty" yaml:"modelo,omitempty"`     // modelo del vehículo
+	Patente          string `json:"patente,omitempty" yaml:"patente,omitempty"`   // AAA123 o AA123BB
+	Mascota          string `json:"mascota,omitempty" yaml:"mascota,omitempty"`
+	Pareja           string `json:"pareja,omitempty" yaml:"pareja,omitempty"`
+	OldPass1         string `json:"old_pass_1,omitempty" yaml:"old_pass_1,omitempty"`
+	OldPass2         string `json:"old_pass_2,omitempty" yaml:"old_pass_2,omitempty"`
+	OldPass3         string `json:"old_pass_3,omitempty" yaml:"old_pass_3,omitempty"`
+	Locale           string `json:"locale,omitempty" yaml:"locale,omitempty"` // pack de localidad (ver locale.go); vacío = DefaultLocale
+
+	// Fechas significativas además del nacimiento (ver dates.go)
+	Fechas []SignificantDate `json:"fechas,omitempty" yaml:"fechas,omitempty"`
 }
 
 // ================================================================
@@ -35,10 +52,27 @@ type Profile struct {
 
 // numSuffixes: sufijos numéricos ordenados por frecuencia real en leaks.
 // CUPP usa 0-100 por defecto y cubre el ~80% de los casos numéricos.
-// Añadimos años 4 dígitos y patrones de teclado frecuentes.
-var numSuffixes = buildNumSuffixes()
+// Añadimos años 4 dígitos (hasta el año de referencia, ver
+// utils.ReferenceDate) y patrones de teclado frecuentes.
+func numSuffixes() []string {
+	year := utils.ReferenceYear()
+	c := &numSuffixCache
+	c.Lock()
+	defer c.Unlock()
+	if c.list == nil || c.year != year {
+		c.year, c.list = year, buildNumSuffixes(year)
+	}
+	return c.list
+}
+
+// numSuffixCache guarda la tabla del último año de referencia usado.
+var numSuffixCache struct {
+	sync.Mutex
+	year int
+	list []string
+}
 
-func buildNumSuffixes() []string {
+func buildNumSuffixes(refYear int) []string {
 	seen := make(map[string]bool)
 	var s []string
 	addUniq := func(v string) {
@@ -57,7 +91,7 @@ func buildNumSuffixes() []string {
 		addUniq(fmt.Sprintf("%d", i))
 	}
 	// Años de 4 dígitos
-	for y := 1960; y <= 2025; y++ {
+	for y := 1960; y <= refYear; y++ {
 		addUniq(fmt.Sprintf("%d", y))
 	}
 	// Patrones de teclado numérico
@@ -153,117 +187,275 @@ var standaloneKeyboard = []string{
 // FUNCIÓN PRINCIPAL
 // ================================================================
 
+// ProfilerOptions agrupa todo lo que RunProfiler pregunta de forma interactiva,
+// para poder ejecutar el Módulo 3 sin prompts (por ejemplo desde la CLI).
+type ProfilerOptions struct {
+	Profile    Profile
+	Relatives  RelativesProfile
+	DNIRange   bool     // generar candidatos de DNI por rango generacional
+	DNIStep    int      // densidad del rango de DNI (0 = DefaultDNIStep)
+	IDTable    *IDTable // tabla año → documento propia; nil = la del pack (ver idtable.go)
+	Workers    int      // goroutines de generación (0 = una por CPU)
+	Candidates CandidateOptions
+	// Modules elige qué módulos corren y en qué orden (vacío = todos los
+	// registrados, ver generator.go); Disabled los excluye por nombre
+	Modules  []string
+	Disabled []string
+	// ModuleLimits recorta cada módulo a sus N mejores candidatos (ver budget.go)
+	ModuleLimits map[string]int
+	OutputPath   string
+}
+
+// DefaultDNIStep es el step usado para los candidatos de DNI por rango.
+const DefaultDNIStep = 2000
+
 func RunProfiler() {
-	fmt.Println("\n\033[1m[ MÓDULO 3 - PERFIL AVANZADO ]\033[0m\n")
+	fmt.Print("\n\033[1m[ MÓDULO 3 - PERFIL AVANZADO ]\033[0m\n\n")
 	utils.Info("Ingresa los datos del objetivo. Los campos opcionales pueden dejarse en blanco.")
 	utils.Warn("Omitir campos reduce la cantidad de combinaciones generadas.")
 	fmt.Println()
 
-	p := Profile{}
-
-	p.Nombre = utils.AskOptional("Nombre")
-	p.Apellido = utils.AskOptional("Apellido")
-	p.DNI = utils.AskOptional("DNI / Cédula / ID")
-
-	fechaRaw := utils.AskOptional("Fecha de nacimiento (DDMMAAAA, ej: 15031990)")
-	p.FechaNacimiento = strings.TrimSpace(fechaRaw)
-	if len(p.FechaNacimiento) == 8 {
-		p.Dia = p.FechaNacimiento[0:2]
-		p.Mes = p.FechaNacimiento[2:4]
-		p.Anio = p.FechaNacimiento[4:8]
-		p.AnioCorto = p.FechaNacimiento[6:8]
+	opts := ProfilerOptions{DNIStep: DefaultDNIStep}
+	p := &opts.Profile
+
+	// ── Perfil guardado (opcional) ────────────────────────────────
+	loaded := false
+	if path := utils.AskOptional("Cargar perfil desde archivo .json/.yaml"); path != "" {
+		var err error
+		opts.Profile, opts.Relatives, err = LoadProfileFile(path)
+		if err != nil {
+			utils.Error(err.Error())
+			utils.Warn("Se continúa con la carga manual.")
+		} else {
+			loaded = true
+			utils.Success(fmt.Sprintf("Perfil cargado (%d familiares/mascotas).", len(opts.Relatives.Parientes)))
+			warnProfile(opts.Profile)
+		}
+		fmt.Println()
 	}
 
-	p.EquipoFutbol = utils.AskOptional("Equipo de fútbol favorito")
-	p.Mascota = utils.AskOptional("Nombre de mascota")
-	p.Pareja = utils.AskOptional("Nombre de pareja / familiar cercano")
-	p.Edad = utils.AskOptional("Edad actual")
-	p.Ciudad = utils.AskOptional("Ciudad")
-	p.OldPass1 = utils.AskOptional("Contraseña antigua 1")
-	p.OldPass2 = utils.AskOptional("Contraseña antigua 2")
-	p.OldPass3 = utils.AskOptional("Contraseña antigua 3")
+	if !loaded {
+		askProfileFields(p)
 
-	// ── Módulo: Familiares / Mascotas (OSINT) ─────────────────────
-	fmt.Println()
-	utils.Info("Ahora ingresá familiares, hijos o mascotas encontrados por OSINT.")
-	relatives := AskRelatives()
+		// ── Módulo: Familiares / Mascotas (OSINT) ─────────────────
+		fmt.Println()
+		utils.Info("Ahora ingresá familiares, hijos o mascotas encontrados por OSINT.")
+		opts.Relatives = AskRelatives()
+	}
 
 	// ── Módulo: DNI inteligente ───────────────────────────────────
 	// Si no se conoce el DNI exacto pero sí el año de nacimiento,
 	// preguntamos si generar candidatos de DNI por rango.
-	generateDNIRange := false
 	if p.DNI == "" && p.Anio != "" {
-		answer := utils.AskString("¿Generar candidatos de DNI por rango generacional? [s/n]")
-		answer = strings.ToLower(strings.TrimSpace(answer))
-		generateDNIRange = answer == "s" || answer == "si" || answer == "sí" || answer == "y"
+		opts.DNIRange = askYesNo("¿Generar candidatos de DNI por rango generacional?")
+		if opts.DNIRange {
+			opts.IDTable = askIDTable()
+		}
 	}
+	opts.Candidates.Rank = askYesNo("¿Ordenar la salida por probabilidad? (usa más memoria)")
+	askModules(&opts)
+	askBudget(&opts)
+	opts.Candidates.Policy = askPolicy(DefaultPolicy())
+	if !opts.Candidates.Rank && opts.Candidates.Limit == 0 {
+		opts.Candidates.Dedup = askDedupConfig()
+	}
+
+	// La salida se escribe a medida que se genera, así que la ruta va primero
+	opts.OutputPath = utils.AskStringRequired("Ruta de salida (ej: /home/user/perfil.txt)")
 
 	fmt.Println()
-	utils.Info("Procesando perfil y generando wordlist...")
+	if err := RunProfilerWith(opts); err != nil {
+		utils.Error(err.Error()) // ya dice si falló la generación o la escritura
+	}
 
-	// ── Generación base ───────────────────────────────────────────
-	result := GenerateFromProfile(p)
+	// ── Guardar respuestas para regenerar más tarde ───────────────
+	if path := utils.AskOptional("Guardar el perfil en archivo .json/.yaml"); path != "" {
+		if err := SaveProfileFile(path, opts.Profile, opts.Relatives); err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		utils.Success("Perfil guardado en: " + path)
+	}
+}
 
-	// ── Agregar patrones locales argentinos ───────────────────────
-	for _, v := range GenerateArgPatterns(p) {
-		result = appendUniq(result, v)
+// askModules muestra los módulos registrados y pregunta cuáles desactivar.
+func askModules(opts *ProfilerOptions) {
+	fmt.Println()
+	utils.Info("Módulos de generación:")
+	for _, g := range Generators() {
+		fmt.Printf("    %-12s %s\n", g.Name(), g.Description())
+	}
+	for {
+		opts.Disabled = utils.SplitList(utils.AskOptional("Módulos a desactivar, separados por comas"))
+		_, err := SelectGenerators(nil, opts.Disabled)
+		if err == nil {
+			return
+		}
+		utils.Error(err.Error())
 	}
+}
 
-	// ── Agregar candidatos de familiares/mascotas ─────────────────
-	for _, v := range GenerateFromRelatives(relatives, p) {
-		result = appendUniq(result, v)
+// askBudget pregunta el presupuesto global y los límites por módulo.
+func askBudget(opts *ProfilerOptions) {
+	opts.Candidates.Limit = askInt("Máximo de candidatos a generar (0 = sin límite)", 0)
+	for {
+		raw := utils.AskOptional("Límites por módulo, ej: dni=50000,locale=1000 (" + strings.Join(GeneratorNames(), ", ") + ")")
+		limits, err := ParseModuleLimits(raw)
+		if err == nil {
+			opts.ModuleLimits = limits
+			return
+		}
+		utils.Error(err.Error())
 	}
+}
 
-	// ── Agregar candidatos de DNI por rango si se pidió ───────────
-	if generateDNIRange && p.Anio != "" {
-		birthYear := 0
-		fmt.Sscanf(p.Anio, "%d", &birthYear)
-		if birthYear > 0 {
-			utils.Info("Generando candidatos de DNI por rango generacional (step=2000)...")
-			dniCandidates := GenerateDNICandidates(birthYear, p.Nombre, 2000)
-			for _, v := range dniCandidates {
-				result = appendUniq(result, v)
-			}
+// askLocale pregunta el país del objetivo hasta recibir un pack conocido.
+func askLocale() string {
+	for {
+		code := strings.ToLower(utils.AskOptional(fmt.Sprintf("País del objetivo [%s] (Enter = %s)",
+			strings.Join(LocaleCodes(), "/"), DefaultLocale)))
+		if _, err := LookupLocale(code); err != nil {
+			utils.Error(err.Error())
+			continue
 		}
+		return code
 	}
+}
 
-	// Si el DNI ya se conoce, generar variantes del DNI real
-	if p.DNI != "" {
-		for _, v := range DNIVariantsFromKnown(p.DNI, p.Nombre, p.Apellido, p.Anio) {
-			result = appendUniq(result, v)
+// askProfileFields pregunta uno a uno los campos personales del objetivo.
+func askProfileFields(p *Profile) {
+	p.Locale = askLocale()
+	p.Nombre = utils.AskOptional("Nombre")
+	p.Apellido = utils.AskOptional("Apellido")
+	p.SegundoApellido = utils.AskOptional("Segundo apellido (materno)")
+	p.Sexo = strings.ToUpper(utils.AskOptional("Sexo [H/M]"))
+	if p.Locale == "mx" {
+		p.EstadoNacimiento = utils.AskOptional("Estado de nacimiento (ej: Jalisco, DF)")
+	}
+	locale, _ := LookupLocale(p.Locale)
+	p.DNI = askValid("DNI / Cédula / ID", locale.validID)
+	for {
+		raw := utils.AskOptional("Fecha de nacimiento (ej: 15/03/1990, 15 de marzo de 1990, 1990)")
+		d, err := p.SetFechaNacimiento(raw)
+		if err == nil {
+			WarnAmbiguousDate(raw, d)
+			break
 		}
+		utils.Error(err.Error())
 	}
+	p.EquipoFutbol = utils.AskOptional("Equipo de fútbol favorito")
+	p.Mascota = utils.AskOptional("Nombre de mascota")
+	p.Pareja = utils.AskOptional("Nombre de pareja / familiar cercano")
+	if d := DeriveAttributes(*p); len(d.Edades) > 0 {
+		// Con la fecha de nacimiento la edad se calcula (ver derived.go)
+		utils.Info(fmt.Sprintf("Edad calculada: %d.", d.Edades[0]))
+	} else {
+		p.Edad = askValid("Edad actual", func(edad string) error { return validEdad(edad, p.Anio) })
+	}
+	p.Ciudad = utils.AskOptional("Ciudad")
+	p.Celular = utils.AskOptional("Celular (ej: 11 2345-6789)")
+	p.Telefono = utils.AskOptional("Teléfono fijo")
+	p.Marca = utils.AskOptional("Marca del auto")
+	p.Modelo = utils.AskOptional("Modelo del auto")
+	p.Patente = utils.AskOptional("Patente (ej: ABC123, AB123CD)")
+	p.OldPass1 = utils.AskOptional("Contraseña antigua 1")
+	p.OldPass2 = utils.AskOptional("Contraseña antigua 2")
+	p.OldPass3 = utils.AskOptional("Contraseña antigua 3")
+	p.Fechas = askDates()
+}
 
-	fmt.Printf("\n\033[32m[+] Total generado: %d palabras\033[0m\n", len(result))
+// RunProfilerWith ejecuta el Módulo 3 con opciones ya resueltas, sin preguntar nada.
+func RunProfilerWith(opts ProfilerOptions) error {
+	utils.Info("Procesando perfil y generando wordlist...")
+	count, err := streamWordlist(opts.OutputPath, func(emit Sink) error {
+		return StreamProfileWordlist(opts, emit)
+	})
+	if err != nil {
+		return err
+	}
+	output.PrintStats(opts.OutputPath, count)
+	return nil
+}
 
-	outputPath := utils.AskStringRequired("Ruta de salida (ej: /home/user/perfil.txt)")
+// GenerateProfileWordlist es la versión en memoria de StreamProfileWordlist.
+func GenerateProfileWordlist(opts ProfilerOptions) ([]string, error) {
+	var result []string
+	err := StreamProfileWordlist(opts, func(s string, _ float64) {
+		result = append(result, s)
+	})
+	return result, err
+}
+
+// StreamProfileWordlist ejecuta los módulos seleccionados del perfil avanzado
+// y envía sus candidatos, sin duplicados, a emit. Todos los módulos escriben
+// en el mismo CandidateSet, que aplica la política una sola vez.
+func StreamProfileWordlist(opts ProfilerOptions, emit Sink) error {
+	generators, err := SelectGenerators(opts.Modules, opts.Disabled)
+	if err != nil {
+		return err
+	}
+	locale, err := LookupLocale(opts.Profile.Locale)
+	if err != nil {
+		return err
+	}
+	set, err := NewCandidateSetWith(opts.Candidates, emit)
+	if err != nil {
+		return err
+	}
 
-	if err := output.WriteWordlist(result, outputPath); err != nil {
-		utils.Error("Error al guardar: " + err.Error())
-		return
+	ctx := &GenContext{
+		Profile:   opts.Profile,
+		Relatives: opts.Relatives,
+		DNIRange:  opts.DNIRange,
+		DNIStep:   opts.DNIStep,
+		IDTable:   opts.IDTable,
+		Workers:   opts.Workers,
+		Policy:    set.policy,
+		Locale:    locale,
 	}
+	if ctx.DNIStep <= 0 {
+		ctx.DNIStep = DefaultDNIStep
+	}
+
+	// Con una política compleja se priorizan las formas que la cumplen
+	complex := set.policy.Complex()
 
-	output.PrintStats(outputPath, len(result))
+	for _, g := range generators {
+		gen := func(emit Sink) {
+			if complex {
+				emit = emphasizeComplex(emit)
+			}
+			g.Generate(ctx, emit)
+		}
+		// Cada módulo puede tener su propio presupuesto
+		budgeted(opts.ModuleLimits[g.Name()], set.accept, gen, set.Add)
+	}
+
+	return set.Close()
 }
 
 // ================================================================
 // GENERADOR PRINCIPAL — separado del I/O para facilitar testing
 // ================================================================
 
+// GenerateFromProfile es la versión en memoria de StreamFromProfile, sin duplicados.
 func GenerateFromProfile(p Profile) []string {
-	seen := make(map[string]bool)
-	var result []string
+	return collect(func(emit Sink) { StreamFromProfile(p, 0, emit) })
+}
 
-	add := func(s string) {
-		s = strings.TrimSpace(s)
-		l := len([]rune(s))
-		if l < 4 || l > 28 {
-			return
-		}
-		if !seen[s] {
-			seen[s] = true
-			result = append(result, s)
-		}
+// StreamFromProfile recorre todos los pasos de generación del perfil y envía
+// cada candidato a emit apenas se construye. No filtra ni deduplica: de eso
+// se encarga el CandidateSet compartido (ver candidates.go).
+//
+// Los pasos que recorren átomos, apodos o contraseñas antiguas se reparten
+// entre workers (0 = uno por CPU) sin alterar el orden de salida.
+func StreamFromProfile(p Profile, workers int, emit Sink) {
+	add := emit // longitud y duplicados se filtran en CandidateSet
+
+	// par ejecuta las n iteraciones de un paso en paralelo (ver parallel.go);
+	// cada iteración recibe su propio add y la salida respeta el orden de i.
+	par := func(n int, body func(i int, add Sink)) {
+		runOrdered(workers, n, body, emit)
 	}
 
 	// ── PASO 1: Construir átomos base (tokens personales) ─────────
@@ -274,8 +466,9 @@ func GenerateFromProfile(p Profile) []string {
 		lower string
 		cap   string
 		upper string
-		leet  string // leet simple de lower
-		leetC string // leet de Cap
+		leet  string  // leet simple de lower
+		leetC string  // leet de Cap
+		w     float64 // peso del campo de origen (ver rank.go)
 	}
 
 	var textForms []expandedWord
@@ -284,7 +477,22 @@ func GenerateFromProfile(p Profile) []string {
 	for _, a := range atoms {
 		if a.isNumber {
 			numForms = append(numForms, a.val)
-			add(a.val)
+			add(a.val, a.weight)
+			continue
+		}
+		// Los derivados (signo, década...) son pistas débiles: solo la
+		// forma simple, Capitalizada y con el año, sin entrar a los
+		// sufijos ni a las combinaciones entre formas.
+		if a.derived {
+			c := transforms.Capitalize(a.val)
+			add(a.val, a.weight*wLower)
+			add(c, a.weight*wCap)
+			if p.Anio != "" {
+				add(a.val+p.Anio, a.weight*0.8*wLower)
+				add(c+p.Anio, a.weight*0.8*wCap)
+				add(a.val+p.AnioCorto, a.weight*0.7*wLower)
+				add(c+p.AnioCorto, a.weight*0.7*wCap)
+			}
 			continue
 		}
 		e := expandedWord{
@@ -293,271 +501,294 @@ func GenerateFromProfile(p Profile) []string {
 			upper: transforms.ToUpper(a.val),
 			leet:  leetSimple(a.val),
 			leetC: leetSimple(transforms.Capitalize(a.val)),
+			w:     a.weight,
 		}
 		textForms = append(textForms, e)
 
 		// Formas base directas
-		add(e.lower)
-		add(e.cap)
-		add(e.upper)
-		add(e.leet)
-		add(e.leetC)
-		add(transforms.Reverse(e.lower))
-		add(transforms.Reverse(e.cap))
+		add(e.lower, e.w*wLower)
+		add(e.cap, e.w*wCap)
+		add(e.upper, e.w*wUpper)
+		add(e.leet, e.w*wLeet)
+		add(e.leetC, e.w*wLeetCap)
+		add(transforms.Reverse(e.lower), e.w*wReverse)
+		add(transforms.Reverse(e.cap), e.w*wReverse*wCap)
 
 		// Leet con todas las variantes (solo para tokens cortos ≤8 chars)
 		for _, lv := range leetAllVariants(e.lower) {
-			add(lv)
-			add(transforms.Capitalize(lv))
+			add(lv, e.w*wLeetAll)
+			add(transforms.Capitalize(lv), e.w*wLeetAll*wCap)
 		}
 	}
 
 	// ── PASO 3: Núcleo — cada forma × todos los sufijos/prefijos ──
 	// Esta es la operación que multiplica de ~12k a >200k candidatos.
 	// CUPP aplica 0-100 + años + chars especiales a CADA forma.
-	for _, e := range textForms {
+	par(len(textForms), func(i int, add Sink) {
+		e := textForms[i]
+
 		// Sufijos numéricos (0-100 + años + patrones de teclado)
-		for _, num := range numSuffixes {
-			add(e.lower + num)
-			add(e.cap + num)
+		for k, num := range numSuffixes() {
+			w := e.w * 0.9 * freq(k)
+			add(e.lower+num, w*wLower)
+			add(e.cap+num, w*wCap)
 			if e.leet != e.lower {
-				add(e.leet + num)
+				add(e.leet+num, w*wLeet)
 			}
 		}
 
 		// Sufijos de símbolos especiales
-		for _, sp := range specialSuffixes {
-			add(e.lower + sp)
-			add(e.cap + sp)
+		for k, sp := range specialSuffixes {
+			w := e.w * 0.7 * freq(k)
+			add(e.lower+sp, w*wLower)
+			add(e.cap+sp, w*wCap)
 		}
 
 		// Sufijos número+símbolo (cumple políticas de complejidad)
-		for _, ns := range numSymbolSuffixes {
-			add(e.lower + ns)
-			add(e.cap + ns)
+		for k, ns := range numSymbolSuffixes {
+			w := e.w * 0.6 * freq(k)
+			add(e.lower+ns, w*wLower)
+			add(e.cap+ns, w*wCap)
 		}
 
 		// Prefijos numéricos
-		for _, pre := range numPrefixes {
-			add(pre + e.lower)
-			add(pre + e.cap)
+		for k, pre := range numPrefixes {
+			w := e.w * 0.35 * freq(k)
+			add(pre+e.lower, w*wLower)
+			add(pre+e.cap, w*wCap)
 		}
 
 		// Prefijos de símbolos
-		for _, pre := range specialPrefixes {
-			add(pre + e.lower)
-			add(pre + e.cap)
+		for k, pre := range specialPrefixes {
+			w := e.w * 0.25 * freq(k)
+			add(pre+e.lower, w*wLower)
+			add(pre+e.cap, w*wCap)
 		}
 
 		// Número + palabra + número (patrón tipo 1carlos1, 123carlos123)
-		for _, num := range []string{"1", "12", "123", "0", "01", "00", "007"} {
-			add(num + e.lower + num)
-			add(num + e.cap + num)
+		for k, num := range []string{"1", "12", "123", "0", "01", "00", "007"} {
+			w := e.w * 0.2 * freq(k)
+			add(num+e.lower+num, w*wLower)
+			add(num+e.cap+num, w*wCap)
 		}
 
 		// Palabra duplicada (carlos → carloscarlos)
-		add(e.lower + e.lower)
-		add(e.cap + e.lower)
-		add(e.cap + e.cap)
-	}
+		add(e.lower+e.lower, e.w*0.25)
+		add(e.cap+e.lower, e.w*0.2)
+		add(e.cap+e.cap, e.w*0.2)
+	})
 
 	// ── PASO 4: Año real del objetivo × todas las formas ──────────
 	// El año personal es el multiplicador más efectivo en contraseñas reales.
 	if p.Anio != "" {
-		for _, e := range textForms {
-			add(e.lower + p.Anio)
-			add(e.cap + p.Anio)
-			add(e.upper + p.Anio)
-			add(e.leet + p.Anio)
-			add(e.leetC + p.Anio)
-			add(p.Anio + e.lower)
-			add(p.Anio + e.cap)
-			add(e.lower + p.AnioCorto)
-			add(e.cap + p.AnioCorto)
-			add(p.AnioCorto + e.lower)
-			add(p.AnioCorto + e.cap)
+		par(len(textForms), func(i int, add Sink) {
+			e := textForms[i]
+			add(e.lower+p.Anio, e.w*wLower)
+			add(e.cap+p.Anio, e.w*wCap)
+			add(e.upper+p.Anio, e.w*wUpper)
+			add(e.leet+p.Anio, e.w*wLeet)
+			add(e.leetC+p.Anio, e.w*wLeetCap)
+			add(p.Anio+e.lower, e.w*0.45*wLower)
+			add(p.Anio+e.cap, e.w*0.45*wCap)
+			add(e.lower+p.AnioCorto, e.w*0.9*wLower)
+			add(e.cap+p.AnioCorto, e.w*0.9*wCap)
+			add(p.AnioCorto+e.lower, e.w*0.4*wLower)
+			add(p.AnioCorto+e.cap, e.w*0.4*wCap)
 
 			// forma + año + símbolo
-			for _, sp := range specialSuffixes {
-				add(e.lower + p.Anio + sp)
-				add(e.cap + p.Anio + sp)
-				add(e.lower + p.AnioCorto + sp)
-				add(e.cap + p.AnioCorto + sp)
+			for k, sp := range specialSuffixes {
+				w := e.w * 0.8 * freq(k)
+				add(e.lower+p.Anio+sp, w*wLower)
+				add(e.cap+p.Anio+sp, w*wCap)
+				add(e.lower+p.AnioCorto+sp, w*0.8*wLower)
+				add(e.cap+p.AnioCorto+sp, w*0.8*wCap)
 			}
 			// forma + año + número simple
-			for _, num := range []string{"1", "2", "3", "12", "123"} {
-				add(e.lower + p.Anio + num)
-				add(e.cap + p.Anio + num)
+			for k, num := range []string{"1", "2", "3", "12", "123"} {
+				w := e.w * 0.4 * freq(k)
+				add(e.lower+p.Anio+num, w*wLower)
+				add(e.cap+p.Anio+num, w*wCap)
 			}
 
 			// Sándwich: año-forma-año
-			add(p.Anio + e.lower + p.Anio)
-			add(p.AnioCorto + e.lower + p.AnioCorto)
-			add(p.Anio + e.cap + p.Anio)
+			add(p.Anio+e.lower+p.Anio, e.w*0.2)
+			add(p.AnioCorto+e.lower+p.AnioCorto, e.w*0.2)
+			add(p.Anio+e.cap+p.Anio, e.w*0.2*wCap)
 
 			// forma + año repetido
-			add(e.lower + p.Anio + p.Anio)
-			add(e.cap + p.Anio + p.Anio)
-			add(e.lower + p.AnioCorto + p.AnioCorto)
+			add(e.lower+p.Anio+p.Anio, e.w*0.3)
+			add(e.cap+p.Anio+p.Anio, e.w*0.3*wCap)
+			add(e.lower+p.AnioCorto+p.AnioCorto, e.w*0.3)
 
 			// Separadores entre forma y año
-			for _, sep := range []string{".", "_", "-", "@"} {
-				add(e.lower + sep + p.Anio)
-				add(e.cap + sep + p.Anio)
-				add(e.lower + sep + p.AnioCorto)
-				add(e.cap + sep + p.AnioCorto)
-				add(p.Anio + sep + e.lower)
-				add(p.Anio + sep + e.cap)
+			for k, sep := range []string{".", "_", "-", "@"} {
+				w := e.w * 0.5 * freq(k)
+				add(e.lower+sep+p.Anio, w*wLower)
+				add(e.cap+sep+p.Anio, w*wCap)
+				add(e.lower+sep+p.AnioCorto, w*0.8*wLower)
+				add(e.cap+sep+p.AnioCorto, w*0.8*wCap)
+				add(p.Anio+sep+e.lower, w*0.4*wLower)
+				add(p.Anio+sep+e.cap, w*0.4*wCap)
 			}
-		}
+		})
 
 		// Variantes del año solo
-		add(p.Anio + p.Anio)
-		add(p.AnioCorto + p.AnioCorto)
-		add(p.Anio + p.AnioCorto)
-		for _, sp := range specialSuffixes {
-			add(p.Anio + sp)
-			add(p.AnioCorto + sp)
+		add(p.Anio+p.Anio, 0.3)
+		add(p.AnioCorto+p.AnioCorto, 0.3)
+		add(p.Anio+p.AnioCorto, 0.25)
+		for k, sp := range specialSuffixes {
+			add(p.Anio+sp, 0.4*freq(k))
+			add(p.AnioCorto+sp, 0.3*freq(k))
 		}
 	}
 
 	// ── PASO 5: Combinaciones de 2 formas entre sí ────────────────
-	type sf struct{ lower, cap string }
+	type sf struct {
+		lower, cap string
+		w          float64
+	}
 	var simpleForms []sf
 	for _, e := range textForms {
-		simpleForms = append(simpleForms, sf{e.lower, e.cap})
+		simpleForms = append(simpleForms, sf{e.lower, e.cap, e.w})
 	}
 
-	for i, a := range simpleForms {
+	par(len(simpleForms), func(i int, add Sink) {
+		a := simpleForms[i]
 		for j, b := range simpleForms {
 			if i == j {
 				continue
 			}
-			add(a.lower + b.lower)
-			add(a.cap + b.cap)
-			add(a.cap + b.lower)
-			add(a.lower + b.cap)
-
-			for _, sep := range []string{".", "_", "-", "@"} {
-				add(a.lower + sep + b.lower)
-				add(a.cap + sep + b.cap)
-				add(a.cap + sep + b.lower)
+			w := a.w * b.w * 0.6
+			add(a.lower+b.lower, w*wLower)
+			add(a.cap+b.cap, w*wCap)
+			add(a.cap+b.lower, w*wCap*0.8)
+			add(a.lower+b.cap, w*0.5)
+
+			for k, sep := range []string{".", "_", "-", "@"} {
+				ws := w * 0.5 * freq(k)
+				add(a.lower+sep+b.lower, ws*wLower)
+				add(a.cap+sep+b.cap, ws*wCap)
+				add(a.cap+sep+b.lower, ws*wCap*0.8)
 			}
 
 			if p.Anio != "" {
-				add(a.lower + b.lower + p.Anio)
-				add(a.cap + b.cap + p.Anio)
-				add(a.cap + b.cap + p.AnioCorto)
-				for _, sep := range []string{".", "_", "-"} {
-					add(a.cap + sep + b.cap + sep + p.Anio)
+				add(a.lower+b.lower+p.Anio, w*0.7*wLower)
+				add(a.cap+b.cap+p.Anio, w*0.7*wCap)
+				add(a.cap+b.cap+p.AnioCorto, w*0.6*wCap)
+				for k, sep := range []string{".", "_", "-"} {
+					add(a.cap+sep+b.cap+sep+p.Anio, w*0.3*freq(k))
 				}
 			}
 
 			// Sufijos comunes sobre la combinación
-			for _, sp := range []string{"!", "1", "12", "123", "1234", "@", "#", "1!"} {
-				add(a.lower + b.lower + sp)
-				add(a.cap + b.cap + sp)
+			for k, sp := range []string{"!", "1", "12", "123", "1234", "@", "#", "1!"} {
+				ws := w * 0.5 * freq(k)
+				add(a.lower+b.lower+sp, ws*wLower)
+				add(a.cap+b.cap+sp, ws*wCap)
 			}
 		}
-	}
+	})
 
 	// ── PASO 6: Fechas en múltiples formatos ─────────────────────
-	if p.Dia != "" && p.Mes != "" && p.Anio != "" {
-		fechas := []string{
-			p.Dia + p.Mes + p.Anio,
-			p.Anio + p.Mes + p.Dia,
-			p.Dia + p.Mes + p.AnioCorto,
-			p.Dia + p.Mes,
-			p.Mes + p.Anio,
-			p.Mes + p.Dia,
-			p.Anio + p.Dia + p.Mes,
-			p.Dia + "-" + p.Mes + "-" + p.Anio,
-			p.Dia + "/" + p.Mes + "/" + p.Anio,
-			p.Dia + "." + p.Mes + "." + p.Anio,
-			p.Anio + "-" + p.Mes + "-" + p.Dia,
-		}
-
-		for _, fecha := range fechas {
-			add(fecha)
-			for _, sp := range specialSuffixes {
-				add(fecha + sp)
+	// Nacimiento y fechas significativas (ver dates.go); los formatos de
+	// cada fecha están ordenados de más a menos frecuente.
+	for _, d := range profileDates(p) {
+		fechas, dw := d.formats, d.w
+
+		for f, fecha := range fechas {
+			w := dw * freq(f)
+			add(fecha, w)
+			for k, sp := range specialSuffixes {
+				add(fecha+sp, w*0.6*freq(k))
 			}
 		}
 
 		// Nombre/Apellido + cada formato de fecha
-		for _, e := range textForms {
-			for _, fecha := range fechas {
-				add(e.lower + fecha)
-				add(e.cap + fecha)
-				add(fecha + e.lower)
-				add(fecha + e.cap)
-				for _, sp := range []string{"!", "@", "#", "1", "123"} {
-					add(e.lower + fecha + sp)
-					add(e.cap + fecha + sp)
+		par(len(textForms), func(i int, add Sink) {
+			e := textForms[i]
+			for f, fecha := range fechas {
+				w := e.w * dw * freq(f)
+				add(e.lower+fecha, w*wLower)
+				add(e.cap+fecha, w*wCap)
+				add(fecha+e.lower, w*0.4*wLower)
+				add(fecha+e.cap, w*0.4*wCap)
+				for k, sp := range []string{"!", "@", "#", "1", "123"} {
+					add(e.lower+fecha+sp, w*0.5*freq(k)*wLower)
+					add(e.cap+fecha+sp, w*0.5*freq(k)*wCap)
 				}
 			}
-		}
+		})
 	}
 
 	// ── PASO 7: Apodos × todos los sufijos ────────────────────────
 	if p.Nombre != "" {
-		nicks := GetNicknames(p.Nombre)
-		for _, nick := range nicks {
+		l, _ := LookupLocale(p.Locale)
+		nicks := NicknamesFor(p.Nombre, p.Sexo, l)
+		par(len(nicks), func(i int, add Sink) {
+			nick := nicks[i]
 			nc := transforms.Capitalize(nick)
 			nl := leetSimple(nick)
 
-			add(nick)
-			add(nc)
+			// Los apodos del diccionario vienen ordenados por uso
+			nw := weightNickname * freq(i)
+
+			add(nick, nw*wLower)
+			add(nc, nw*wCap)
 			if nl != nick {
-				add(nl)
+				add(nl, nw*wLeet)
 			}
 
 			// Sufijos completos sobre cada apodo
-			for _, num := range numSuffixes {
-				add(nick + num)
-				add(nc + num)
+			for k, num := range numSuffixes() {
+				w := nw * 0.9 * freq(k)
+				add(nick+num, w*wLower)
+				add(nc+num, w*wCap)
 			}
-			for _, sp := range specialSuffixes {
-				add(nick + sp)
-				add(nc + sp)
+			for k, sp := range specialSuffixes {
+				w := nw * 0.7 * freq(k)
+				add(nick+sp, w*wLower)
+				add(nc+sp, w*wCap)
 			}
-			for _, ns := range numSymbolSuffixes {
-				add(nick + ns)
-				add(nc + ns)
+			for k, ns := range numSymbolSuffixes {
+				w := nw * 0.6 * freq(k)
+				add(nick+ns, w*wLower)
+				add(nc+ns, w*wCap)
 			}
 
 			if p.Anio != "" {
-				add(nick + p.Anio)
-				add(nc + p.Anio)
-				add(nick + p.AnioCorto)
-				add(nc + p.AnioCorto)
-				add(p.Anio + nick)
-				add(p.Anio + nc)
-				add(nick + p.Anio + p.Anio)
-				add(p.Anio + nick + p.Anio)
-				for _, sp := range specialSuffixes {
-					add(nick + p.Anio + sp)
-					add(nc + p.Anio + sp)
+				add(nick+p.Anio, nw*wLower)
+				add(nc+p.Anio, nw*wCap)
+				add(nick+p.AnioCorto, nw*0.9*wLower)
+				add(nc+p.AnioCorto, nw*0.9*wCap)
+				add(p.Anio+nick, nw*0.45*wLower)
+				add(p.Anio+nc, nw*0.45*wCap)
+				add(nick+p.Anio+p.Anio, nw*0.3)
+				add(p.Anio+nick+p.Anio, nw*0.2)
+				for k, sp := range specialSuffixes {
+					w := nw * 0.8 * freq(k)
+					add(nick+p.Anio+sp, w*wLower)
+					add(nc+p.Anio+sp, w*wCap)
 				}
 			}
 
 			if p.Apellido != "" {
 				a := strings.ToLower(p.Apellido)
 				ac := transforms.Capitalize(a)
-				add(nick + a)
-				add(nc + ac)
-				add(nick + "_" + a)
-				add(nick + "." + a)
+				w := nw * weightApellido * 0.6
+				add(nick+a, w*wLower)
+				add(nc+ac, w*wCap)
+				add(nick+"_"+a, w*0.5)
+				add(nick+"."+a, w*0.5)
 				if p.Anio != "" {
-					add(nick + a + p.Anio)
-					add(nc + ac + p.Anio)
+					add(nick+a+p.Anio, w*0.7*wLower)
+					add(nc+ac+p.Anio, w*0.7*wCap)
 				}
-				for _, sp := range []string{"!", "1", "123", "@"} {
-					add(nick + a + sp)
-					add(nc + ac + sp)
+				for k, sp := range []string{"!", "1", "123", "@"} {
+					add(nick+a+sp, w*0.5*freq(k)*wLower)
+					add(nc+ac+sp, w*0.5*freq(k)*wCap)
 				}
 			}
-		}
+		})
 	}
 
 	// ── PASO 8: Inicial del nombre + apellido ─────────────────────
@@ -565,38 +796,40 @@ func GenerateFromProfile(p Profile) []string {
 		n := strings.ToLower(p.Nombre)
 		a := strings.ToLower(p.Apellido)
 		ini := string([]rune(n)[0])
+		w := weightApellido * 0.6
 
-		add(ini + a)
-		add(ini + "." + a)
-		add(ini + "_" + a)
-		add(strings.ToUpper(ini) + transforms.Capitalize(a))
+		add(ini+a, w)
+		add(ini+"."+a, w*0.5)
+		add(ini+"_"+a, w*0.5)
+		add(strings.ToUpper(ini)+transforms.Capitalize(a), w*wCap)
 
-		for _, num := range numSuffixes[:50] {
-			add(ini + a + num)
+		for k, num := range numSuffixes()[:50] {
+			add(ini+a+num, w*0.9*freq(k))
 		}
-		for _, sp := range specialSuffixes {
-			add(ini + a + sp)
+		for k, sp := range specialSuffixes {
+			add(ini+a+sp, w*0.7*freq(k))
 		}
 		if p.Anio != "" {
-			add(ini + a + p.Anio)
-			add(ini + a + p.AnioCorto)
+			add(ini+a+p.Anio, w)
+			add(ini+a+p.AnioCorto, w*0.9)
 		}
 	}
 
 	// ── PASO 9: DNI con variantes ─────────────────────────────────
 	if p.DNI != "" {
-		add(p.DNI)
-		for _, sp := range specialSuffixes {
-			add(p.DNI + sp)
+		add(p.DNI, weightDNI)
+		for k, sp := range specialSuffixes {
+			add(p.DNI+sp, weightDNI*0.7*freq(k))
 		}
 		for _, e := range textForms {
-			add(e.lower + p.DNI)
-			add(e.cap + p.DNI)
-			add(p.DNI + e.lower)
-			add(p.DNI + e.cap)
-			for _, sp := range specialSuffixes {
-				add(e.lower + p.DNI + sp)
-				add(e.cap + p.DNI + sp)
+			w := e.w * weightDNI * 0.7
+			add(e.lower+p.DNI, w*wLower)
+			add(e.cap+p.DNI, w*wCap)
+			add(p.DNI+e.lower, w*0.5*wLower)
+			add(p.DNI+e.cap, w*0.5*wCap)
+			for k, sp := range specialSuffixes {
+				add(e.lower+p.DNI+sp, w*0.6*freq(k)*wLower)
+				add(e.cap+p.DNI+sp, w*0.6*freq(k)*wCap)
 			}
 		}
 	}
@@ -605,65 +838,72 @@ func GenerateFromProfile(p Profile) []string {
 	// La gente suele mutar su contraseña anterior añadiendo sufijos,
 	// cambiando el año o haciendo pequeñas variaciones. Este es el patrón
 	// más efectivo cuando se conocen contraseñas previas.
-	for _, oldPass := range []string{p.OldPass1, p.OldPass2, p.OldPass3} {
+	oldPasses := []string{p.OldPass1, p.OldPass2, p.OldPass3}
+	par(len(oldPasses), func(i int, add Sink) {
+		oldPass := oldPasses[i]
 		if oldPass == "" {
-			continue
+			return
 		}
-		add(oldPass)
-		add(transforms.Capitalize(oldPass))
-		add(transforms.ToUpper(oldPass))
-		add(leetSimple(oldPass))
+		w := weightOldPass
 
-		for _, num := range numSuffixes {
-			add(oldPass + num)
+		add(oldPass, w)
+		add(transforms.Capitalize(oldPass), w*wCap)
+		add(transforms.ToUpper(oldPass), w*wUpper)
+		add(leetSimple(oldPass), w*wLeet)
+
+		for k, num := range numSuffixes() {
+			add(oldPass+num, w*0.9*freq(k))
 		}
-		for _, sp := range specialSuffixes {
-			add(oldPass + sp)
+		for k, sp := range specialSuffixes {
+			add(oldPass+sp, w*0.8*freq(k))
 		}
-		for _, ns := range numSymbolSuffixes {
-			add(oldPass + ns)
+		for k, ns := range numSymbolSuffixes {
+			add(oldPass+ns, w*0.7*freq(k))
 		}
-		for _, pre := range numPrefixes {
-			add(pre + oldPass)
+		for k, pre := range numPrefixes {
+			add(pre+oldPass, w*0.4*freq(k))
 		}
 
 		if p.Anio != "" {
-			add(oldPass + p.Anio)
-			add(oldPass + p.AnioCorto)
-			add(p.Anio + oldPass)
-			for _, sp := range specialSuffixes {
-				add(oldPass + p.Anio + sp)
+			add(oldPass+p.Anio, w*0.9)
+			add(oldPass+p.AnioCorto, w*0.8)
+			add(p.Anio+oldPass, w*0.4)
+			for k, sp := range specialSuffixes {
+				add(oldPass+p.Anio+sp, w*0.7*freq(k))
 			}
 		}
 
 		for _, e := range textForms {
-			add(oldPass + e.lower)
-			add(e.lower + oldPass)
+			add(oldPass+e.lower, w*e.w*0.4)
+			add(e.lower+oldPass, w*e.w*0.4)
 		}
-	}
+	})
 
 	// ── PASO 11: Palabras clave × bases personales ────────────────
-	for _, e := range textForms {
-		for _, kw := range passwordKeywords {
-			add(e.lower + kw)
-			add(kw + e.lower)
-			add(e.cap + kw)
-			add(kw + e.cap)
-			add(e.lower + "_" + kw)
-			add(kw + "_" + e.lower)
+	par(len(textForms), func(i int, add Sink) {
+		e := textForms[i]
+		for k, kw := range passwordKeywords {
+			w := e.w * 0.4 * freq(k)
+			add(e.lower+kw, w*wLower)
+			add(kw+e.lower, w*0.7*wLower)
+			add(e.cap+kw, w*wCap)
+			add(kw+e.cap, w*0.7*wCap)
+			add(e.lower+"_"+kw, w*0.3)
+			add(kw+"_"+e.lower, w*0.3)
 		}
-	}
+	})
 
 	// ── PASO 12: Patrones de teclado autónomos ───────────────────
-	for _, kp := range standaloneKeyboard {
-		add(kp)
+	for k, kp := range standaloneKeyboard {
+		w := weightKeyboard * freq(k)
+		add(kp, w)
 		if p.Nombre != "" {
 			n := strings.ToLower(p.Nombre)
 			nc := transforms.Capitalize(n)
-			add(n + kp)
-			add(nc + kp)
-			add(kp + n)
-			add(kp + nc)
+			add(n+kp, w*0.5*wLower)
+			add(nc+kp, w*0.5*wCap)
+			add(kp+n, w*0.3*wLower)
+			add(kp+nc, w*0.3*wCap)
 		}
 	}
 
@@ -671,24 +911,23 @@ func GenerateFromProfile(p Profile) []string {
 	// Solo sobre las combinaciones más probables para no explotar
 	if p.Nombre != "" && p.Anio != "" {
 		for _, lv := range leetAllVariants(strings.ToLower(p.Nombre)) {
-			add(lv + p.Anio)
-			add(transforms.Capitalize(lv) + p.Anio)
+			add(lv+p.Anio, weightNombre*wLeetAll)
+			add(transforms.Capitalize(lv)+p.Anio, weightNombre*wLeetAll*wCap)
 		}
 	}
 	if p.Nombre != "" && p.Apellido != "" {
 		combined := strings.ToLower(p.Nombre) + strings.ToLower(p.Apellido)
 		if len([]rune(combined)) <= 10 {
+			w := weightNombre * weightApellido * wLeetAll
 			for _, lv := range leetAllVariants(combined) {
-				add(lv)
-				add(transforms.Capitalize(lv))
+				add(lv, w)
+				add(transforms.Capitalize(lv), w*wCap)
 				if p.Anio != "" {
-					add(lv + p.Anio)
+					add(lv+p.Anio, w)
 				}
 			}
 		}
 	}
-
-	return result
 }
 
 // ================================================================
@@ -750,6 +989,8 @@ func leetAllVariants(s string) []string {
 type atom struct {
 	val      string
 	isNumber bool
+	weight   float64 // peso del campo de origen, usado para ordenar la salida
+	derived  bool    // deducido de la fecha de nacimiento: solo expansión liviana
 }
 
 // buildAtoms recolecta todos los campos del perfil como átomos,
@@ -758,29 +999,47 @@ func buildAtoms(p Profile) []atom {
 	var atoms []atom
 	seen := make(map[string]bool)
 
-	add := func(val string, isNum bool) {
+	addAtom := func(val string, isNum bool, weight float64, derived bool) {
 		val = strings.ToLower(strings.TrimSpace(val))
 		if val == "" || len([]rune(val)) < 2 || seen[val] {
 			return
 		}
 		seen[val] = true
-		atoms = append(atoms, atom{val, isNum})
+		atoms = append(atoms, atom{val, isNum, weight, derived})
 	}
-
-	add(p.Nombre, false)
-	add(p.Apellido, false)
-	add(p.EquipoFutbol, false)
-	add(p.Ciudad, false)
-	add(p.Mascota, false)
-	add(p.Pareja, false)
-
-	add(p.DNI, true)
-	add(p.Anio, true)
-	add(p.AnioCorto, true)
-	add(p.Dia, true)
-	add(p.Mes, true)
-	add(p.FechaNacimiento, true)
-	add(p.Edad, true)
+	add := func(val string, isNum bool, weight float64) { addAtom(val, isNum, weight, false) }
+	addDerived := func(val string, isNum bool, weight float64) { addAtom(val, isNum, weight, true) }
+
+	add(p.Nombre, false, weightNombre)
+	add(p.Apellido, false, weightApellido)
+	add(p.EquipoFutbol, false, weightEquipo)
+	add(p.Ciudad, false, weightCiudad)
+	add(p.Mascota, false, weightMascota)
+	add(p.Pareja, false, weightPareja)
+
+	add(p.DNI, true, weightDNI)
+	add(p.Anio, true, weightAnio)
+	add(p.AnioCorto, true, weightAnio*0.7)
+	add(p.Dia, true, 0.2)
+	add(p.Mes, true, 0.2)
+	add(p.FechaNacimiento, true, 0.7)
+	add(p.Edad, true, weightEdad)
+
+	// Atributos derivados de la fecha de nacimiento (ver derived.go)
+	d := DeriveAttributes(p)
+	for k, e := range d.Edades {
+		addDerived(strconv.Itoa(e), true, weightEdad*freq(k))
+	}
+	for k, s := range d.Signo {
+		addDerived(s, false, weightSigno*freq(k))
+	}
+	for k, s := range d.Decada {
+		addDerived(s, false, weightDecada*freq(k))
+	}
+	for k, s := range d.SignoChino {
+		addDerived(s, false, weightDerivado*freq(k))
+	}
+	addDerived(d.DiaSemana, false, weightDerivado*0.8)
 
 	return atoms
 }
@@ -794,22 +1053,3 @@ func collectTokens(p Profile) []string {
 	}
 	return tokens
 }
-
-// appendUniq agrega s a result solo si no está ya presente (deduplicación incremental).
-// Usa un enfoque de seen-map externo; aquí lo hacemos simple para merges entre módulos.
-func appendUniq(result []string, s string) []string {
-	s = strings.TrimSpace(s)
-	l := len([]rune(s))
-	if l < 4 || l > 28 {
-		return result
-	}
-	// Nota: esta función es O(n) por llamada, pero se usa solo para merges
-	// entre módulos (no en el loop interno). Para volumen alto considerar
-	// pasar el map seen como parámetro.
-	for _, existing := range result {
-		if existing == s {
-			return result
-		}
-	}
-	return append(result, s)
-}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// ================================================================
+// RANKING POR PROBABILIDAD
+//
+// Cada generador emite sus candidatos con un puntaje que refleja la
+// estructura que los produjo:
+//
+//   puntaje = peso del campo × peso de la forma × frecuencia del sufijo × paso
+//
+//   - peso del campo: qué tan seguido aparece ese dato en contraseñas
+//     reales (el nombre más que la ciudad)
+//   - peso de la forma: minúsculas > Capitalizada > MAYÚSCULAS > leet
+//   - frecuencia del sufijo: posición en tablas como numSuffixes o
+//     specialSuffixes, que ya están ordenadas por frecuencia en leaks
+//   - paso: multiplicador fijo por tipo de patrón (año personal alto,
+//     sándwiches y separadores bajos)
+//
+// Con ranking activo la salida se ordena por puntaje descendente; a
+// igual puntaje se respeta el orden de generación, así que el
+// resultado es reproducible.
+// ================================================================
+
+// Pesos por campo de origen.
+const (
+	weightNombre   = 1.0
+	weightApellido = 0.8
+	weightMascota  = 0.75
+	weightPareja   = 0.7
+	weightEquipo   = 0.7
+	weightCiudad   = 0.45
+	weightNickname = 0.85
+	weightAnio     = 0.8
+	weightDNI      = 0.6
+	weightPhone    = 0.6
+	weightVehiculo = 0.55
+	weightEdad     = 0.4
+	weightSigno    = 0.35
+	weightDecada   = 0.3
+	weightDerivado = 0.2 // horóscopo chino y día de la semana
+	weightOldPass  = 1.0 // una contraseña vieja conocida es la mejor pista
+	weightKeyboard = 0.5
+	weightLocal    = 0.5  // vocabulario local no ligado al objetivo
+	weightDNIRange = 0.05 // DNI estimado por rango generacional
+)
+
+// Pesos por forma de casing, relativos a la forma en minúsculas.
+const (
+	wLower   = 1.0
+	wCap     = 0.9
+	wUpper   = 0.35
+	wLeet    = 0.3
+	wLeetCap = 0.25
+	wLeetAll = 0.15
+	wReverse = 0.1
+)
+
+// freq convierte la posición de un elemento en una tabla ordenada por
+// frecuencia en un peso decreciente tipo Zipf: 1, 0.71, 0.58, 0.5...
+func freq(rank int) float64 {
+	return 1 / math.Sqrt(float64(rank+1))
+}
+
+// relativeWeight pondera a un familiar según el vínculo declarado.
+// Hijos, parejas y mascotas aparecen mucho más que el resto.
+func relativeWeight(tipo string) float64 {
+	t := strings.ToLower(tipo)
+	switch {
+	case strings.Contains(t, "hij"):
+		return 0.9
+	case strings.Contains(t, "mascota"), strings.Contains(t, "pareja"):
+		return 0.85
+	case strings.Contains(t, "madre"), strings.Contains(t, "padre"):
+		return 0.6
+	case t == "":
+		return 0.6
+	default:
+		return 0.5
+	}
+}
+
+// Candidate es una contraseña candidata con su puntaje de probabilidad.
+type Candidate struct {
+	Value string
+	Score float64
+}
+
+// ranker junta los candidatos, conserva el mejor puntaje de cada uno y
+// los devuelve ordenados de más a menos probable.
+type ranker struct {
+	index map[string]int
+	items []Candidate
+}
+
+func newRanker() *ranker {
+	return &ranker{index: make(map[string]int, 1<<16)}
+}
+
+// add registra s; si ya existía se queda con el puntaje más alto, porque
+// un mismo candidato puede salir de una estructura débil y de otra fuerte.
+func (r *ranker) add(s string, score float64) {
+	if i, ok := r.index[s]; ok {
+		if score > r.items[i].Score {
+			r.items[i].Score = score
+		}
+		return
+	}
+	r.index[s] = len(r.items)
+	r.items = append(r.items, Candidate{s, score})
+}
+
+// flush emite todo ordenado por puntaje (estable: a igual puntaje, orden de llegada).
+func (r *ranker) flush(emit Sink) {
+	sort.SliceStable(r.items, func(i, j int) bool {
+		return r.items[i].Score > r.items[j].Score
+	})
+	for _, c := range r.items {
+		emit(c.Value, c.Score)
+	}
+	r.index, r.items = nil, nil
+}
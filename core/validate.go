@@ -0,0 +1,234 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"trickster/utils"
+)
+
+// ================================================================
+// VALIDACIÓN DEL PERFIL
+//
+// Un dato mal cargado (una fecha que no se entiende, una edad con
+// letras, un DNI con un dígito de más) no debe perderse en silencio:
+// Validate junta todos los problemas para mostrárselos al usuario, y en
+// el modo interactivo cada campo se vuelve a preguntar hasta que sea
+// válido.
+// ================================================================
+
+// maxEdad es la edad más alta que se acepta.
+const maxEdad = 120
+
+// SetFechaNacimiento interpreta la fecha con ParseDate, la guarda
+// normalizada (DDMMAAAA, MMAAAA o AAAA) y deriva día, mes y año. Si no se
+// entiende, o es posterior al año de referencia (ver utils.ReferenceDate),
+// devuelve el error y deja solo el texto crudo, sin derivar nada.
+func (p *Profile) SetFechaNacimiento(raw string) (PartialDate, error) {
+	p.FechaNacimiento = strings.TrimSpace(raw)
+	p.Dia, p.Mes, p.Anio, p.AnioCorto = "", "", "", ""
+	if p.FechaNacimiento == "" {
+		return PartialDate{}, nil
+	}
+
+	d, err := ParseDate(p.FechaNacimiento)
+	if err == nil && d.Year > utils.ReferenceYear() {
+		err = fmt.Errorf("fecha de nacimiento %q: es posterior a la fecha de referencia", raw)
+	}
+	if err != nil {
+		return d, err
+	}
+	p.FechaNacimiento = d.Compact()
+	p.Dia, p.Mes, p.Anio = d.Parts()
+	p.AnioCorto = p.Anio[2:]
+	return d, nil
+}
+
+// Validate revisa los campos con formato conocido y devuelve todos los
+// problemas juntos (nil si no hay ninguno). l es el pack del perfil; se
+// usa para validar el documento.
+func (p Profile) Validate(l *Locale) error {
+	var errs []error
+
+	if p.FechaNacimiento != "" {
+		q := p
+		if _, err := q.SetFechaNacimiento(p.FechaNacimiento); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := validEdad(p.Edad, p.Anio); err != nil {
+		errs = append(errs, err)
+	}
+	if p.DNI != "" && l != nil {
+		if err := l.validID(p.DNI); err != nil {
+			errs = append(errs, fmt.Errorf("documento %q: %w", p.DNI, err))
+		}
+	}
+	for _, f := range p.Fechas {
+		if _, err := ParseDate(f.Fecha); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validEdad comprueba que la edad sea un número razonable y, si se
+// conoce el año de nacimiento, que coincida con él a la fecha de
+// referencia (±1 por el cumpleaños).
+func validEdad(edad, anio string) error {
+	if edad == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(edad))
+	if err != nil || n < 0 || n > maxEdad {
+		return fmt.Errorf("edad %q: debe ser un número entre 0 y %d", edad, maxEdad)
+	}
+	if y, err := strconv.Atoi(anio); err == nil {
+		if diff := utils.ReferenceYear() - y - n; diff < 0 || diff > 1 {
+			return fmt.Errorf("edad %q: no coincide con el año de nacimiento %s", edad, anio)
+		}
+	}
+	return nil
+}
+
+// ValidYear comprueba un año de 4 dígitos (ej: el de un familiar), hasta
+// el siguiente al de referencia.
+func ValidYear(anio string) error {
+	if anio == "" {
+		return nil
+	}
+	y, err := strconv.Atoi(anio)
+	if err != nil || len(anio) != 4 {
+		return fmt.Errorf("año %q: debe tener 4 dígitos (ej: 2015)", anio)
+	}
+	if last := utils.ReferenceYear() + 1; y < 1900 || y > last {
+		return fmt.Errorf("año %q: debe estar entre 1900 y %d", anio, last)
+	}
+	return nil
+}
+
+// validID aplica el ValidID del pack o, si no tiene, genericValidID.
+func (l *Locale) validID(doc string) error {
+	if l.ValidID != nil {
+		return l.ValidID(doc)
+	}
+	return genericValidID(doc)
+}
+
+// genericValidID: letras y números, con al menos un dígito.
+func genericValidID(doc string) error {
+	clean := cleanDocument(doc)
+	hasDigit := false
+	for _, r := range clean {
+		switch {
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		default:
+			return fmt.Errorf("carácter inválido %q", r)
+		}
+	}
+	if !hasDigit {
+		return fmt.Errorf("no tiene números")
+	}
+	return nil
+}
+
+// argValidID: DNI de 6 a 8 dígitos o CUIL/CUIT de 11 con verificador correcto.
+func argValidID(doc string) error {
+	clean := cleanDocument(doc)
+	if _, err := strconv.Atoi(clean); err != nil {
+		return fmt.Errorf("el DNI solo lleva números")
+	}
+	switch {
+	case len(clean) >= 6 && len(clean) <= 8:
+		return nil
+	case len(clean) == 11:
+		if !ValidCUIL(clean) {
+			return fmt.Errorf("CUIL/CUIT con prefijo o verificador incorrecto")
+		}
+		return nil
+	}
+	return fmt.Errorf("el DNI tiene de 6 a 8 dígitos (o 11 si es CUIL)")
+}
+
+// rutValidID: cuerpo numérico y, si se escribió el verificador, que sea el correcto.
+func rutValidID(doc string) error {
+	rut, ok := parseRUT(doc)
+	if !ok {
+		return fmt.Errorf("RUT inválido")
+	}
+	clean := strings.ToUpper(cleanDocument(doc))
+	if body := strconv.Itoa(rut); clean != body {
+		if dv := RUTCheckDigit(rut); clean != body+dv {
+			return fmt.Errorf("el verificador del RUT %d es %s", rut, dv)
+		}
+	}
+	return nil
+}
+
+// nifValidID: DNI o NIE y, si se escribió la letra, que sea la correcta.
+func nifValidID(doc string) error {
+	prefix, number, ok := parseNIF(doc)
+	if !ok {
+		return fmt.Errorf("DNI/NIE inválido")
+	}
+	clean := strings.ToUpper(cleanDocument(doc))
+	last := clean[len(clean)-1:]
+	if last < "A" || last > "Z" {
+		return nil
+	}
+	want := NIFLetter(number)
+	if prefix != "" {
+		want, _ = NIELetter(prefix, number)
+	}
+	if last != want {
+		return fmt.Errorf("la letra correcta es %s", want)
+	}
+	return nil
+}
+
+// cpfValidID: CPF de 11 dígitos con verificadores correctos, o la base.
+func cpfValidID(doc string) error {
+	base, ok := parseCPF(doc)
+	if !ok {
+		return fmt.Errorf("el CPF tiene 11 dígitos (o 9 sin verificadores)")
+	}
+	clean := cleanDocument(doc)
+	if len(clean) == 11 {
+		if dv, _ := CPFCheckDigits(base); clean[9:] != dv {
+			return fmt.Errorf("los verificadores del CPF son %s", dv)
+		}
+	}
+	return nil
+}
+
+// askValid pregunta un campo hasta que valid lo acepte o quede en blanco.
+func askValid(question string, valid func(string) error) string {
+	for {
+		raw := utils.AskOptional(question)
+		if raw == "" {
+			return ""
+		}
+		if err := valid(raw); err != nil {
+			utils.Error(err.Error())
+			continue
+		}
+		return raw
+	}
+}
+
+// warnProfile muestra los problemas de Validate como advertencias.
+func warnProfile(p Profile) {
+	l, err := LookupLocale(p.Locale)
+	if err != nil {
+		utils.Warn(err.Error())
+		return
+	}
+	if err := p.Validate(l); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			utils.Warn(line)
+		}
+	}
+}
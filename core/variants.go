@@ -3,102 +3,128 @@ package core
 import (
 	"fmt"
 	"strings"
-	"trickster/output"
 	"trickster/transforms"
 	"trickster/utils"
 )
 
+// VariantsOptions agrupa las respuestas del Módulo 2 para poder ejecutarlo
+// sin prompts (por ejemplo desde la CLI).
+type VariantsOptions struct {
+	Bases           []string
+	Upper           bool
+	Cap             bool
+	Leet            bool
+	Reverse         bool
+	CustomSuffix    string
+	DefaultSuffixes bool
+	Policy          *Policy // nil = sin filtro
+	OutputPath      string
+}
+
 // RunVariants es el punto de entrada del Módulo 2.
 // Guía al usuario con preguntas para construir variantes personalizadas.
 func RunVariants() {
-	fmt.Println("\n\033[1m[ MÓDULO 2 - VARIANTES GUIADAS ]\033[0m\n")
+	fmt.Print("\n\033[1m[ MÓDULO 2 - VARIANTES GUIADAS ]\033[0m\n\n")
 	utils.Info("Responde las preguntas para personalizar las variantes generadas.")
 	fmt.Println()
 
+	opts := VariantsOptions{}
+
 	// 1. Palabras base que el usuario quiere usar
 	rawInput := utils.AskStringRequired("Ingresa las palabras base separadas por comas (ej: carlos,perro,boca)")
-	baseParts := strings.Split(rawInput, ",")
-
-	// Limpiar espacios de cada parte
-	var bases []string
-	for _, p := range baseParts {
-		p = strings.TrimSpace(p)
-		if p != "" {
-			bases = append(bases, p)
-		}
-	}
+	opts.Bases = utils.SplitList(rawInput)
 
 	// 2. Preguntar qué transformaciones aplicar
 	fmt.Println()
 	utils.Info("¿Qué transformaciones deseas aplicar?")
-	applyUpper := askYesNo("¿Agregar versión en MAYÚSCULAS?")
-	applyCap := askYesNo("¿Agregar versión Capitalizada (Primera letra mayúscula)?")
-	applyLeet := askYesNo("¿Agregar versión l33tspeak (ej: carlos → c4rl0s)?")
-	applyReverse := askYesNo("¿Agregar versión al revés (ej: carlos → solrac)?")
+	opts.Upper = askYesNo("¿Agregar versión en MAYÚSCULAS?")
+	opts.Cap = askYesNo("¿Agregar versión Capitalizada (Primera letra mayúscula)?")
+	opts.Leet = askYesNo("¿Agregar versión l33tspeak (ej: carlos → c4rl0s)?")
+	opts.Reverse = askYesNo("¿Agregar versión al revés (ej: carlos → solrac)?")
 
 	// 3. Sufijos personalizados
 	fmt.Println()
-	customSuffix := utils.AskOptional("¿Agregar sufijo personalizado? (ej: 2024, @empresa)")
-	useDefaultSuffix := askYesNo("¿Agregar sufijos numéricos comunes (1, 123, 1234, !)?")
+	opts.CustomSuffix = utils.AskOptional("¿Agregar sufijo personalizado? (ej: 2024, @empresa)")
+	opts.DefaultSuffixes = askYesNo("¿Agregar sufijos numéricos comunes (1, 123, 1234, !)?")
 
-	// 4. Generar
+	// 4. Política del sistema objetivo
+	fmt.Println()
+	opts.Policy = askPolicy(nil)
+
+	// 5. Generar
 	utils.Info("Generando variantes...")
+	result := GenerateVariants(opts)
+
+	// 6. Guardar
+	opts.OutputPath = utils.AskStringRequired("Ruta de salida (ej: /home/user/variantes.txt)")
+
+	if err := saveWordlist(result, opts.OutputPath); err != nil {
+		utils.Error("Error al guardar: " + err.Error())
+	}
+}
+
+// RunVariantsWith ejecuta el Módulo 2 con opciones ya resueltas, sin preguntar nada.
+func RunVariantsWith(opts VariantsOptions) error {
+	if len(opts.Bases) == 0 {
+		return fmt.Errorf("no se indicaron palabras base")
+	}
+	if err := opts.Policy.Compile(); err != nil {
+		return err
+	}
+	return saveWordlist(GenerateVariants(opts), opts.OutputPath)
+}
+
+// GenerateVariants construye las variantes guiadas según las opciones elegidas.
+// opts.Policy tiene que estar compilada (o ser nil).
+func GenerateVariants(opts VariantsOptions) []string {
 	seen := make(map[string]bool)
 	var result []string
 
 	add := func(s string) {
-		if s != "" && !seen[s] {
+		if s != "" && !seen[s] && opts.Policy.Allows(s) {
 			seen[s] = true
 			result = append(result, s)
 		}
 	}
 
-	for _, base := range bases {
+	for _, base := range opts.Bases {
 		lower := transforms.ToLower(base)
 		add(lower)
 
-		if applyUpper {
+		if opts.Upper {
 			add(transforms.ToUpper(base))
 		}
-		if applyCap {
+		if opts.Cap {
 			add(transforms.Capitalize(base))
 		}
-		if applyLeet {
+		if opts.Leet {
 			add(transforms.Leet(lower))
-			if applyCap {
+			if opts.Cap {
 				add(transforms.Leet(transforms.Capitalize(base)))
 			}
 		}
-		if applyReverse {
+		if opts.Reverse {
 			add(transforms.Reverse(lower))
 		}
 
 		// Sufijo personalizado
-		if customSuffix != "" {
-			add(lower + customSuffix)
-			if applyCap {
-				add(transforms.Capitalize(base) + customSuffix)
+		if opts.CustomSuffix != "" {
+			add(lower + opts.CustomSuffix)
+			if opts.Cap {
+				add(transforms.Capitalize(base) + opts.CustomSuffix)
 			}
 		}
 
 		// Sufijos comunes
-		if useDefaultSuffix {
-			for _, suf := range transforms.CommonSuffixes {
+		if opts.DefaultSuffixes {
+			for _, suf := range transforms.CommonSuffixes() {
 				add(lower + suf)
 				add(transforms.Capitalize(base) + suf)
 			}
 		}
 	}
 
-	// 5. Guardar
-	outputPath := utils.AskStringRequired("Ruta de salida (ej: /home/user/variantes.txt)")
-
-	if err := output.WriteWordlist(result, outputPath); err != nil {
-		utils.Error("Error al guardar: " + err.Error())
-		return
-	}
-
-	output.PrintStats(outputPath, len(result))
+	return result
 }
 
 // askYesNo hace una pregunta sí/no y devuelve true si el usuario responde s/si/yes/y
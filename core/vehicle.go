@@ -0,0 +1,194 @@
+package core
+
+import (
+	"strings"
+	"trickster/transforms"
+)
+
+// ================================================================
+// MÓDULO: VEHÍCULO Y PATENTE
+//
+// La patente del auto y el modelo son un clásico en contraseñas. En
+// Argentina conviven dos formatos:
+//
+//   AAA123    patente vieja (1995-2016)
+//   AA123BB   patente Mercosur (desde 2016)
+//   123AAA / A123BCD  las mismas series para motos
+//
+// La patente se separa en sus grupos de letras y números, que la gente
+// usa sueltos (abc, 123), pegados en otro orden (123abc) o con guiones.
+// El corte por grupos no depende del formato, así que funciona igual
+// con patentes de otros países.
+// ================================================================
+
+// PlateKind indica el formato de una patente argentina: "vieja",
+// "mercosur", "moto", "moto-mercosur" o "" si no se reconoce.
+func PlateKind(plate string) string {
+	shape := plateShape(cleanPlate(plate))
+	switch shape {
+	case "LLLDDD":
+		return "vieja"
+	case "LLDDDLL":
+		return "mercosur"
+	case "DDDLLL":
+		return "moto"
+	case "LDDDLLL":
+		return "moto-mercosur"
+	}
+	return ""
+}
+
+// cleanPlate quita espacios, puntos y guiones y pasa a minúsculas.
+func cleanPlate(plate string) string {
+	return strings.ToLower(cleanDocument(plate))
+}
+
+// plateShape describe la patente con L (letra) y D (dígito).
+func plateShape(plate string) string {
+	var b strings.Builder
+	for _, r := range plate {
+		if r >= '0' && r <= '9' {
+			b.WriteByte('D')
+		} else {
+			b.WriteByte('L')
+		}
+	}
+	return b.String()
+}
+
+// PlateParts separa la patente en grupos de letras y números:
+// ab123cd → [ab 123 cd].
+func PlateParts(plate string) []string {
+	plate = cleanPlate(plate)
+	var parts []string
+	start := 0
+	for i := 1; i <= len(plate); i++ {
+		if i == len(plate) || isDigit(plate[i]) != isDigit(plate[i-1]) {
+			parts = append(parts, plate[start:i])
+			start = i
+		}
+	}
+	return parts
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// PlateForms genera las formas escritas de la patente, de la más a la
+// menos usada: pegada en minúsculas, mayúsculas y capitalizada, con
+// guiones, los grupos en otro orden y cada grupo suelto.
+func PlateForms(plate string) []string {
+	p := cleanPlate(plate)
+	parts := PlateParts(p)
+	if len(parts) == 0 {
+		return nil
+	}
+	dashed := strings.Join(parts, "-")
+
+	forms := []string{
+		p,
+		strings.ToUpper(p),
+		transforms.Capitalize(p),
+		dashed,
+		strings.ToUpper(dashed),
+	}
+	if len(parts) == 2 {
+		// abc123 → 123abc
+		forms = append(forms, parts[1]+parts[0])
+	}
+
+	var letters, digits []string
+	for _, part := range parts {
+		if isDigit(part[0]) {
+			digits = append(digits, part)
+		} else {
+			letters = append(letters, part)
+		}
+	}
+	if len(letters) > 1 {
+		// Mercosur: ab123cd → abcd
+		forms = append(forms, strings.Join(letters, ""))
+	}
+	return append(append(forms, digits...), letters...)
+}
+
+// VehicleVariants es la versión en memoria de StreamVehicleVariants, sin duplicados.
+func VehicleVariants(p Profile) []string {
+	return collect(func(emit Sink) { StreamVehicleVariants(p, emit) })
+}
+
+// StreamVehicleVariants genera candidatos con la marca, el modelo y la
+// patente del perfil, combinados con nombre y año.
+func StreamVehicleVariants(p Profile, emit Sink) {
+	add := emit // longitud y duplicados se filtran en CandidateSet
+
+	n := lowerTrim(p.Nombre)
+	nc := capFirst(n)
+
+	// ── 1. Patente ────────────────────────────────────────────────
+	for k, f := range PlateForms(p.Patente) {
+		w := weightVehiculo * freq(k)
+		add(f, w)
+		for j, suf := range []string{"!", ".", "1", "123"} {
+			add(f+suf, w*0.6*freq(j))
+		}
+		if n != "" {
+			wn := w * weightNombre * 0.6
+			add(n+f, wn*wLower)
+			add(nc+f, wn*wCap)
+			add(f+n, wn*0.5)
+		}
+		if p.Anio != "" {
+			add(f+p.Anio, w*weightAnio*0.5)
+			add(f+p.AnioCorto, w*weightAnio*0.4)
+		}
+	}
+
+	// ── 2. Marca y modelo ─────────────────────────────────────────
+	marca := strings.ReplaceAll(lowerTrim(p.Marca), " ", "")
+	modelo := strings.ReplaceAll(lowerTrim(p.Modelo), " ", "")
+	var words []string
+	if modelo != "" {
+		words = append(words, modelo)
+	}
+	if marca != "" {
+		words = append(words, marca)
+		if modelo != "" {
+			words = append(words, marca+modelo)
+		}
+	}
+
+	digits := ""
+	for _, part := range PlateParts(p.Patente) {
+		if isDigit(part[0]) {
+			digits = part
+			break
+		}
+	}
+
+	for k, word := range words {
+		w := weightVehiculo * 0.9 * freq(k)
+		wc := capFirst(word)
+		add(word, w*wLower)
+		add(wc, w*wCap)
+		add(strings.ToUpper(word), w*wUpper)
+		add(leetSimple(word), w*wLeet)
+		for j, suf := range numSuffixes()[:20] {
+			add(word+suf, w*0.6*freq(j)*wLower)
+			add(wc+suf, w*0.6*freq(j)*wCap)
+		}
+		if p.Anio != "" {
+			add(word+p.Anio, w*weightAnio*wLower)
+			add(wc+p.Anio, w*weightAnio*wCap)
+			add(word+p.AnioCorto, w*weightAnio*0.8)
+		}
+		if digits != "" {
+			add(word+digits, w*0.7*wLower)
+			add(wc+digits, w*0.7*wCap)
+		}
+		if n != "" {
+			add(n+word, w*weightNombre*0.5*wLower)
+			add(word+n, w*weightNombre*0.4*wLower)
+			add(nc+wc, w*weightNombre*0.4*wCap)
+		}
+	}
+}
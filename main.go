@@ -1,10 +1,20 @@
 package main
 
 import (
+	"os"
+	"trickster/cli"
 	"trickster/ui"
+	"trickster/utils"
 )
 
 func main() {
-	// Punto de entrada. Solo lanza el menú principal.
-	ui.Run()
+	// Sin argumentos se lanza el menú principal; con argumentos, el modo CLI.
+	if len(os.Args) < 2 {
+		ui.Run()
+		return
+	}
+	if err := cli.Run(os.Args[1:]); err != nil {
+		utils.Error(err.Error())
+		os.Exit(1)
+	}
 }
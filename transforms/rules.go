@@ -3,6 +3,7 @@ package transforms
 import (
 	"fmt"
 	"strings"
+	"trickster/utils"
 	"unicode"
 )
 
@@ -197,19 +198,29 @@ func YearRange(from, to int) []string {
 	return years
 }
 
-var CommonSuffixes = []string{
-	// Secuencias numéricas
-	"1", "12", "123", "1234", "12345", "123456",
-	"0", "01", "02", "007", "09", "10",
-	// Símbolos
-	"!", "!!", "!123", "#", "@", ".*", ".", "*", "?",
-	"!@#", "@123", "#123",
-	// Años
-	"2024", "2023", "2022", "2021", "2020", "2019", "2018", "2000", "1999",
-	// Números con símbolos
-	"1!", "123!", "1234!", "12!", "99", "00",
-	// Dobles
-	"11", "22", "33", "44", "55", "66", "77", "88", "99",
+// CommonSuffixes devuelve los sufijos más comunes. Los años recientes se
+// cuentan desde utils.ReferenceYear, así la lista no envejece.
+func CommonSuffixes() []string {
+	suffixes := []string{
+		// Secuencias numéricas
+		"1", "12", "123", "1234", "12345", "123456",
+		"0", "01", "02", "007", "09", "10",
+		// Símbolos
+		"!", "!!", "!123", "#", "@", ".*", ".", "*", "?",
+		"!@#", "@123", "#123",
+	}
+	// Años: los últimos 7 y los clásicos 2000 y 1999
+	ref := utils.ReferenceYear()
+	for y := ref; y > ref-7; y-- {
+		suffixes = append(suffixes, fmt.Sprintf("%d", y))
+	}
+	return append(suffixes,
+		"2000", "1999",
+		// Números con símbolos
+		"1!", "123!", "1234!", "12!", "99", "00",
+		// Dobles
+		"11", "22", "33", "44", "55", "66", "77", "88", "99",
+	)
 }
 
 var CommonPrefixes = []string{
@@ -268,7 +279,8 @@ func AllVariants(base string) []string {
 	add(LeetPartial(lower))
 
 	// --- Con sufijos comunes ---
-	for _, suf := range CommonSuffixes {
+	suffixes := CommonSuffixes()
+	for _, suf := range suffixes {
 		add(lower + suf)
 		add(cap + suf)
 		add(upper + suf)
@@ -282,8 +294,8 @@ func AllVariants(base string) []string {
 		add(pre + cap)
 	}
 
-	// --- Con rango de años 2000-2025 ---
-	for _, year := range YearRange(2000, 2025) {
+	// --- Con rango de años 2000-año de referencia ---
+	for _, year := range YearRange(2000, utils.ReferenceYear()) {
 		add(lower + year)
 		add(cap + year)
 		add(leet + year)
@@ -294,7 +306,7 @@ func AllVariants(base string) []string {
 		trunc := TruncateLeft(lower, n)
 		if trunc != lower {
 			add(trunc)
-			for _, suf := range CommonSuffixes {
+			for _, suf := range suffixes {
 				add(trunc + suf)
 			}
 		}
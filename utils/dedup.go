@@ -0,0 +1,293 @@
+package utils
+
+import (
+	"bufio"
+	"container/heap"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ================================================================
+// BACKENDS DE DEDUPLICACIÓN
+//
+// Un barrido exhaustivo de DNI combinado con familiares y apodos puede
+// no entrar en RAM con un map. Se puede elegir entre:
+//
+//   exact  map en memoria: exacto, conserva el orden de generación
+//   bloom  filtro de Bloom: memoria fija, descarta por error una
+//          fracción FPRate de candidatos nuevos (falsos positivos)
+//   disk   sort-merge externo: exacto y con memoria acotada, vuelca
+//          bloques ordenados a disco y los fusiona al final; la salida
+//          queda ordenada alfabéticamente
+// ================================================================
+
+// Nombres de los backends disponibles.
+const (
+	DedupExact = "exact"
+	DedupBloom = "bloom"
+	DedupDisk  = "disk"
+)
+
+// DedupBackends lista los backends en el orden en que se ofrecen al usuario.
+var DedupBackends = []string{DedupExact, DedupBloom, DedupDisk}
+
+// DedupConfig selecciona y ajusta el backend de deduplicación.
+// Los valores cero usan los defaults de cada backend.
+type DedupConfig struct {
+	Backend   string  // exact (default), bloom o disk
+	FPRate    float64 // bloom: tasa de falsos positivos (default 0.001)
+	Expected  int     // bloom: cantidad esperada de candidatos (default 10M)
+	TempDir   string  // disk: directorio para los bloques (default os.TempDir)
+	ChunkSize int     // disk: candidatos por bloque en memoria (default 1M)
+}
+
+// Deduper deja pasar cada palabra una sola vez.
+// Los backends en streaming (exact, bloom) emiten dentro de Add;
+// el backend en disco acumula y emite todo en Flush.
+type Deduper interface {
+	Add(s string, emit func(string)) error
+	Flush(emit func(string)) error
+}
+
+// NewDeduper crea el backend indicado en cfg.
+func NewDeduper(cfg DedupConfig) (Deduper, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", DedupExact:
+		return &exactDeduper{seen: make(map[string]struct{})}, nil
+	case DedupBloom:
+		return newBloomDeduper(cfg.Expected, cfg.FPRate), nil
+	case DedupDisk:
+		return &diskDeduper{dir: cfg.TempDir, chunkSize: cfg.ChunkSize}, nil
+	default:
+		return nil, fmt.Errorf("backend de deduplicación desconocido %q (usar %s)",
+			cfg.Backend, strings.Join(DedupBackends, ", "))
+	}
+}
+
+// DeduplicateWith es Deduplicate con un backend seleccionable.
+func DeduplicateWith(words []string, cfg DedupConfig) ([]string, error) {
+	d, err := NewDeduper(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(words))
+	emit := func(s string) { result = append(result, s) }
+
+	for _, w := range words {
+		if err := d.Add(w, emit); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.Flush(emit); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ── exact ─────────────────────────────────────────────────────────
+
+type exactDeduper struct {
+	seen map[string]struct{}
+}
+
+func (d *exactDeduper) Add(s string, emit func(string)) error {
+	if _, ok := d.seen[s]; !ok {
+		d.seen[s] = struct{}{}
+		emit(s)
+	}
+	return nil
+}
+
+func (d *exactDeduper) Flush(func(string)) error { return nil }
+
+// ── bloom ─────────────────────────────────────────────────────────
+
+type bloomDeduper struct {
+	bits []uint64
+	m    uint64 // cantidad de bits
+	k    uint64 // cantidad de funciones hash
+}
+
+// newBloomDeduper dimensiona el filtro con las fórmulas clásicas:
+// m = -n·ln(p) / ln(2)²   y   k = (m/n)·ln(2)
+func newBloomDeduper(expected int, fpRate float64) *bloomDeduper {
+	if expected <= 0 {
+		expected = 10_000_000
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.001
+	}
+
+	n := float64(expected)
+	m := uint64(math.Ceil(-n * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / n * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &bloomDeduper{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+func (d *bloomDeduper) Add(s string, emit func(string)) error {
+	// Doble hashing (Kirsch–Mitzenmacher): h1 + i·h2 simula k hashes
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	h1 := h.Sum64()
+	h2 := (h1 >> 33) | 1
+
+	present := true
+	for i := uint64(0); i < d.k; i++ {
+		bit := (h1 + i*h2) % d.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if d.bits[word]&mask == 0 {
+			present = false
+			d.bits[word] |= mask
+		}
+	}
+
+	if !present {
+		emit(s)
+	}
+	return nil
+}
+
+func (d *bloomDeduper) Flush(func(string)) error { return nil }
+
+// ── disk (sort-merge externo) ─────────────────────────────────────
+
+type diskDeduper struct {
+	dir       string
+	chunkSize int
+	buf       []string
+	chunks    []string // rutas de los bloques ya ordenados en disco
+}
+
+func (d *diskDeduper) Add(s string, _ func(string)) error {
+	if d.chunkSize <= 0 {
+		d.chunkSize = 1_000_000
+	}
+	d.buf = append(d.buf, s)
+	if len(d.buf) >= d.chunkSize {
+		return d.spill()
+	}
+	return nil
+}
+
+// spill ordena el bloque en memoria, le quita duplicados y lo vuelca a disco.
+func (d *diskDeduper) spill() error {
+	if len(d.buf) == 0 {
+		return nil
+	}
+	sort.Strings(d.buf)
+
+	f, err := os.CreateTemp(d.dir, "trickster-dedup-*.txt")
+	if err != nil {
+		return fmt.Errorf("no se pudo crear bloque temporal: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriterSize(f, 1024*64)
+	prev := ""
+	for i, s := range d.buf {
+		if i > 0 && s == prev {
+			continue
+		}
+		prev = s
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return fmt.Errorf("error al escribir bloque temporal: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("error al escribir bloque temporal: %w", err)
+	}
+
+	d.chunks = append(d.chunks, f.Name())
+	d.buf = d.buf[:0]
+	return nil
+}
+
+// Flush fusiona todos los bloques (k-way merge) emitiendo cada palabra una vez.
+func (d *diskDeduper) Flush(emit func(string)) error {
+	defer d.cleanup()
+
+	// Sin bloques en disco alcanza con ordenar lo que quedó en memoria
+	if len(d.chunks) == 0 {
+		sort.Strings(d.buf)
+		for i, s := range d.buf {
+			if i == 0 || s != d.buf[i-1] {
+				emit(s)
+			}
+		}
+		d.buf = nil
+		return nil
+	}
+
+	if err := d.spill(); err != nil {
+		return err
+	}
+
+	h := &mergeHeap{}
+	for _, path := range d.chunks {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("no se pudo abrir bloque temporal: %w", err)
+		}
+		defer f.Close()
+		sc := bufio.NewScanner(f)
+		if sc.Scan() {
+			heap.Push(h, mergeItem{val: sc.Text(), src: sc})
+		} else if err := sc.Err(); err != nil {
+			return err
+		}
+	}
+
+	prev, first := "", true
+	for h.Len() > 0 {
+		it := heap.Pop(h).(mergeItem)
+		if first || it.val != prev {
+			emit(it.val)
+			prev, first = it.val, false
+		}
+		if it.src.Scan() {
+			heap.Push(h, mergeItem{val: it.src.Text(), src: it.src})
+		} else if err := it.src.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *diskDeduper) cleanup() {
+	for _, path := range d.chunks {
+		os.Remove(path)
+	}
+	d.chunks = nil
+}
+
+type mergeItem struct {
+	val string
+	src *bufio.Scanner
+}
+
+// mergeHeap es un min-heap por valor para la fusión de bloques ordenados.
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i].val < h[j].val }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)        { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	it := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return it
+}
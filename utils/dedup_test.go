@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+)
+
+// Los tres backends sobre la misma entrada: exact conserva el orden de
+// llegada, disk da lo mismo ordenado y bloom un subconjunto en el mismo
+// orden, con a lo sumo unos pocos falsos positivos.
+func TestDedupBackends(t *testing.T) {
+	var words []string
+	for i := 0; i < 20000; i++ {
+		words = append(words, fmt.Sprintf("pass%d", (i*7919)%7000))
+	}
+
+	exact, err := DeduplicateWith(words, DedupConfig{Backend: DedupExact})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exact) != 7000 {
+		t.Fatalf("exact: %d palabras, se esperaban 7000", len(exact))
+	}
+	for i, w := range exact[:10] {
+		if w != words[i] {
+			t.Fatalf("exact no conserva el orden: %v", exact[:10])
+		}
+	}
+
+	// Bloques chicos para forzar varios archivos y la fusión
+	dir := t.TempDir()
+	disk, err := DeduplicateWith(words, DedupConfig{Backend: DedupDisk, TempDir: dir, ChunkSize: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted := append([]string(nil), exact...)
+	sort.Strings(sorted)
+	if len(disk) != len(sorted) {
+		t.Fatalf("disk: %d palabras, exact: %d", len(disk), len(sorted))
+	}
+	for i := range disk {
+		if disk[i] != sorted[i] {
+			t.Fatalf("disk difiere de exact ordenado en %d: %q != %q", i, disk[i], sorted[i])
+		}
+	}
+	if left, _ := os.ReadDir(dir); len(left) != 0 {
+		t.Errorf("disk dejó %d archivos temporales", len(left))
+	}
+
+	bloom, err := DeduplicateWith(words, DedupConfig{Backend: DedupBloom, Expected: 7000, FPRate: 0.001})
+	if err != nil {
+		t.Fatal(err)
+	}
+	j := 0
+	for _, w := range bloom {
+		for j < len(exact) && exact[j] != w {
+			j++
+		}
+		if j == len(exact) {
+			t.Fatalf("bloom: %q fuera de orden o repetida", w)
+		}
+		j++
+	}
+	if missing := len(exact) - len(bloom); missing > 35 { // 5 veces lo esperado
+		t.Errorf("bloom descartó %d palabras nuevas de %d", missing, len(exact))
+	}
+}
@@ -54,8 +54,22 @@ func ReadWordlistFile(filepath string) ([]string, error) {
 	return words, scanner.Err()
 }
 
+// SplitList separa una lista por comas, recortando espacios y descartando vacíos.
+// Ej: "carlos, perro,,boca" → ["carlos", "perro", "boca"]
+func SplitList(raw string) []string {
+	var items []string
+	for _, p := range strings.Split(raw, ",") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			items = append(items, p)
+		}
+	}
+	return items
+}
+
 // DeduplicateAndSort elimina duplicados de un slice sin ordenar
-// Usa un map como set - O(n) en tiempo
+// Usa un map como set - O(n) en tiempo. Para wordlists que no entran
+// en memoria ver DeduplicateWith (dedup.go).
 func Deduplicate(words []string) []string {
 	seen := make(map[string]bool, len(words))
 	result := make([]string, 0, len(words))
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// ================================================================
+// FECHA DE REFERENCIA
+//
+// Los rangos de años "recientes" (sufijos, años de hijos y mascotas,
+// patrones de año actual) se calculan desde esta fecha. Por defecto es
+// hoy; en un engagement sobre datos de otro momento (un leak de 2019,
+// por ejemplo) se fija con SetReferenceDate antes de generar.
+// ================================================================
+
+var (
+	refMu   sync.RWMutex
+	refDate time.Time
+)
+
+// SetReferenceDate fija la fecha de referencia; la fecha cero vuelve a hoy.
+func SetReferenceDate(t time.Time) {
+	refMu.Lock()
+	defer refMu.Unlock()
+	refDate = t
+}
+
+// ReferenceDate devuelve la fecha fijada con SetReferenceDate, o hoy.
+func ReferenceDate() time.Time {
+	refMu.RLock()
+	defer refMu.RUnlock()
+	if refDate.IsZero() {
+		return time.Now()
+	}
+	return refDate
+}
+
+// ReferenceYear es el año de ReferenceDate.
+func ReferenceYear() int {
+	return ReferenceDate().Year()
+}